@@ -1,26 +1,44 @@
 package bandwidth
 
 import (
+	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
 const accountPath = "account"
 
 // Account struct
 type Account struct {
+	// Balance is parsed from number or string value (the API returns it as string like "538.37250000")
 	Balance     float64 `json:"balance"`
 	AccountType string  `json:"accountType"`
 }
 
+// UnmarshalJSON decodes the account (balance can be number or string)
+func (a *Account) UnmarshalJSON(data []byte) error {
+	type rawAccount Account
+	raw := struct {
+		*rawAccount
+		Balance json.Number `json:"balance"`
+	}{rawAccount: (*rawAccount)(a)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	a.Balance = 0
+	if raw.Balance != "" {
+		balance, err := raw.Balance.Float64()
+		if err != nil {
+			return fmt.Errorf("Invalid account balance %q", raw.Balance)
+		}
+		a.Balance = balance
+	}
+	return nil
+}
+
 // GetAccount returns account information (balance, etc)
 // It returns Account instance or error
 func (api *Client) GetAccount() (*Account, error) {
-	result, _, err := api.makeRequest(http.MethodGet, api.concatUserPath(accountPath), &Account{})
-	if err != nil {
-		return nil, err
-	}
-	return result.(*Account), nil
+	return getTyped[Account](api, api.concatUserPath(accountPath), nil)
 }
 
 // AccountTransaction struct
@@ -34,12 +52,36 @@ type AccountTransaction struct {
 	Number      string  `json:"number"`
 }
 
+// Types of account transactions
+const (
+	AccountTransactionTypeCharge       = "charge"
+	AccountTransactionTypePayment      = "payment"
+	AccountTransactionTypeCredit       = "credit"
+	AccountTransactionTypeAutoRecharge = "auto-recharge"
+)
+
+// GetAccountTransactionsQuery is optional parameters of GetAccountTransactions()
+type GetAccountTransactionsQuery struct {
+	MaxItems int
+	// FromDate and ToDate are RFC 3339 times
+	FromDate string
+	ToDate   string
+	// Type is one of AccountTransactionType* constants
+	Type        string
+	ProductType string
+	Page        int
+	Size        int
+	// QueryParams are raw query parameters (see QueryParams type)
+	QueryParams QueryParams
+}
+
 // GetAccountTransactions returns transactions from the user's account
 // It returns list of AccountTransaction instances or error
-func (api *Client) GetAccountTransactions() ([]*AccountTransaction, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s", api.concatUserPath(accountPath), "transactions"), &[]*AccountTransaction{})
-	if err != nil {
-		return nil, err
+// example: list, err := api.GetAccountTransactions(&bandwidth.GetAccountTransactionsQuery{Type: bandwidth.AccountTransactionTypeCharge, FromDate: "2017-01-01T00:00:00Z"})
+func (api *Client) GetAccountTransactions(query ...*GetAccountTransactionsQuery) ([]*AccountTransaction, error) {
+	var options *GetAccountTransactionsQuery
+	if len(query) > 0 {
+		options = query[0]
 	}
-	return *(result.(*[]*AccountTransaction)), nil
+	return listTyped[AccountTransaction](api, fmt.Sprintf("%s/%s", api.concatUserPath(accountPath), "transactions"), options)
 }
@@ -1,6 +1,7 @@
 package bandwidth
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 )
@@ -64,7 +65,9 @@ func TestGetAccountTransactions(t *testing.T) {
 	}
 	expect(t, len(result), 2)
 	expect(t, result[0].ID, "{transactionId1}")
+	expect(t, result[0].Time, "2013-02-21T13:39:09.122Z")
 	expect(t, result[1].ID, "{transactionId2}")
+	expect(t, result[1].Time, "2013-02-21T13:37:42.079Z")
 }
 
 func TestGetAccountTransactionsFail(t *testing.T) {
@@ -75,3 +78,28 @@ func TestGetAccountTransactionsFail(t *testing.T) {
 	defer server.Close()
 	shouldFail(t, func() (interface{}, error) { return api.GetAccountTransactions() })
 }
+
+func TestGetAccountWithStringBalance(t *testing.T) {
+	account := &Account{}
+	expectNil(t, json.Unmarshal([]byte(`{"balance": "538.37250000", "accountType": "pre-pay"}`), account))
+	expect(t, account.Balance, 538.3725)
+	expect(t, account.AccountType, "pre-pay")
+	if json.Unmarshal([]byte(`{"balance": "unknown"}`), &Account{}) == nil {
+		t.Error("Should fail for invalid balance")
+	}
+}
+
+func TestGetAccountTransactionsWithQuery(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/account/transactions?fromDate=2013-02-21T00%3A00%3A00Z&maxItems=10&type=charge",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "{transactionId1}", "amount": "0.00750", "type": "charge"}]`}})
+	defer server.Close()
+	result, err := api.GetAccountTransactions(&GetAccountTransactionsQuery{
+		MaxItems: 10,
+		FromDate: "2013-02-21T00:00:00Z",
+		Type:     AccountTransactionTypeCharge})
+	expectNil(t, err)
+	expect(t, len(result), 1)
+	expect(t, result[0].Amount, 0.0075)
+}
@@ -25,53 +25,62 @@ type Application struct {
 type GetApplicationsQuery struct {
 	Page int
 	Size int
+	// QueryParams are raw query parameters (see QueryParams type)
+	QueryParams QueryParams
 }
 
 // GetApplications returns list of user's applications
 // It returns list of Application instances or error
 func (api *Client) GetApplications(query ...*GetApplicationsQuery) ([]*Application, error) {
+	list, _, err := api.GetApplicationsPage(query...)
+	return list, err
+}
+
+// GetApplicationsPage returns a page of user's applications like GetApplications() with pagination data of the page
+// It returns list of Application instances, Pagination instance or error
+// example: list, pagination, err := api.GetApplicationsPage(&bandwidth.GetApplicationsQuery{Page: 1})
+func (api *Client) GetApplicationsPage(query ...*GetApplicationsQuery) ([]*Application, *Pagination, error) {
 	var options *GetApplicationsQuery
 	if len(query) > 0 {
 		options = query[0]
 	}
-	result, _, err := api.makeRequest(http.MethodGet, api.concatUserPath(applicationsPath), &[]*Application{}, options)
+	result, pagination, err := api.getListPage(api.concatUserPath(applicationsPath), &[]*Application{}, options)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return *(result.(*[]*Application)), nil
+	return *(result.(*[]*Application)), pagination, nil
 }
 
 // ApplicationData struct
 type ApplicationData struct {
 	Name                              string `json:"name,omitempty"`
-	IncomingCallURL                   string `json:"incomingCallUrl,omitempty"`
+	IncomingCallURL                   string `json:"incomingCallUrl,omitempty" clearable:"true"`
 	IncomingCallURLCallbackTimeout    int    `json:"incomingCallUrlCallbackTimeout,omitempty"`
-	IncomingCallFallbackURL           string `json:"incomingCallFallbackUrl,omitempty"`
-	IncomingMessageURL                string `json:"incomingMessageUrl,omitempty"`
+	IncomingCallFallbackURL           string `json:"incomingCallFallbackUrl,omitempty" clearable:"true"`
+	IncomingMessageURL                string `json:"incomingMessageUrl,omitempty" clearable:"true"`
 	IncomingMessageURLCallbackTimeout int    `json:"incomingMessageUrlCallbackTimeout,omitempty"`
-	IncomingMessageFallbackURL        string `json:"incomingMessageFallbackUrl,omitempty"`
+	IncomingMessageFallbackURL        string `json:"incomingMessageFallbackUrl,omitempty" clearable:"true"`
 	CallbackHTTPMethod                string `json:"callbackHttpMethod,omitempty"`
 	AutoAnswer                        bool   `json:"autoAnswer,omitempty"`
+	// ClearFields lists Go names of fields which should be cleared by UpdateApplication() (sent as null)
+	// Supported fields: IncomingCallURL, IncomingCallFallbackURL, IncomingMessageURL, IncomingMessageFallbackURL
+	ClearFields []string `json:"-"`
 }
 
 // CreateApplication creates an application that can handle calls and messages for one of your phone number. Many phone numbers can share an application.
 // It returns ID of created application or error
 func (api *Client) CreateApplication(data *ApplicationData) (string, error) {
-	_, headers, err := api.makeRequest(http.MethodPost, api.concatUserPath(applicationsPath), nil, data)
+	result, headers, err := api.makeRequest(http.MethodPost, api.concatUserPath(applicationsPath), nil, data)
 	if err != nil {
 		return "", err
 	}
-	return getIDFromLocationHeader(headers), nil
+	return getCreatedID(result, headers)
 }
 
 // GetApplication returns an user's application
 // It returns Application instance or error
 func (api *Client) GetApplication(id string) (*Application, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s", api.concatUserPath(applicationsPath), id), &Application{})
-	if err != nil {
-		return nil, err
-	}
-	return result.(*Application), nil
+	return getTyped[Application](api, fmt.Sprintf("%s/%s", api.concatUserPath(applicationsPath), id), nil)
 }
 
 // UpdateApplication makes changes to an application
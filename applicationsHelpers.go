@@ -0,0 +1,168 @@
+package bandwidth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultCallbackCheckTimeout is used by CheckCallbackURL() when Client.CallbackCheckTimeout is not set
+const DefaultCallbackCheckTimeout = 10 * time.Second
+
+// CheckCallbackURL makes sure that callback url responds before it is used for an application
+// It sends HEAD request (with fallback to GET if HEAD is not allowed) and treats any 2xx, 3xx or 401 response as reachable
+// It returns error object
+// example: err := api.CheckCallbackURL(context.Background(), "http://example.com/calls")
+func (api *Client) CheckCallbackURL(ctx context.Context, callbackURL string) error {
+	timeout := api.CallbackCheckTimeout
+	if timeout <= 0 {
+		timeout = DefaultCallbackCheckTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	statusCode, err := api.probeCallbackURL(ctx, http.MethodHead, callbackURL)
+	if err == nil && (statusCode == http.StatusMethodNotAllowed || statusCode == http.StatusNotImplemented) {
+		statusCode, err = api.probeCallbackURL(ctx, http.MethodGet, callbackURL)
+	}
+	if err != nil {
+		return fmt.Errorf("Callback url %s is unreachable: %s", callbackURL, err.Error())
+	}
+	if (statusCode >= 200 && statusCode < 400) || statusCode == http.StatusUnauthorized {
+		return nil
+	}
+	return fmt.Errorf("Callback url %s is unreachable: http code %d", callbackURL, statusCode)
+}
+
+func (api *Client) probeCallbackURL(ctx context.Context, method, callbackURL string) (int, error) {
+	request, err := http.NewRequest(method, callbackURL, nil)
+	if err != nil {
+		return 0, err
+	}
+	httpClient := api.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	// redirects are treated as reachable, so there is no need to follow them
+	client := *httpClient
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	response, err := client.Do(request.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	drainAndClose(response.Body)
+	return response.StatusCode, nil
+}
+
+// DeprovisionProgress is called by DeprovisionApplication() after each removed resource
+// resource is one of "phoneNumber", "domainEndpoint", "application"
+type DeprovisionProgress func(resource, id string)
+
+// DeprovisionApplication removes an application with all dependent resources:
+// releases its phone numbers, removes domain endpoints linked with the application, then removes the application itself.
+// Domains are not removed: the API doesn't link domains with applications, so after a partial failure
+// there is no way to tell which empty domains belonged to the application. Remove them by DeleteDomain() if need.
+// Resources are looked up before removing, so it is safe to call it again after partial failure
+// (or for already removed application).
+// It returns error object
+// example: api.DeprovisionApplication("applicationId", func(resource, id string) { log.Printf("%s %s removed", resource, id) })
+func (api *Client) DeprovisionApplication(applicationID string, progress ...DeprovisionProgress) error {
+	report := func(resource, id string) {
+		for _, p := range progress {
+			p(resource, id)
+		}
+	}
+	// all pages are read before removing, so removed numbers don't shift next pages
+	numbers, err := listAllTyped[PhoneNumber](api, api.concatUserPath(phoneNumbersPath), &GetPhoneNumbersQuery{ApplicationID: applicationID, Size: maxPageSize})
+	if err != nil {
+		return err
+	}
+	for _, number := range numbers {
+		if err = api.DeletePhoneNumber(number.ID); err != nil {
+			return err
+		}
+		report("phoneNumber", number.ID)
+	}
+	domains, err := listAllTyped[Domain](api, api.concatUserPath(domainsPath), &GetDomainsQuery{Size: maxDomainsPageSize})
+	if err != nil {
+		return err
+	}
+	for _, domain := range domains {
+		endpointsURL := fmt.Sprintf("%s/%s/%s", api.concatUserPath(domainsPath), domain.ID, endpointsPath)
+		endpoints, err := listAllTyped[DomainEndpoint](api, endpointsURL, &GetDomainEndpointsQuery{Size: maxDomainsPageSize})
+		if err != nil {
+			return err
+		}
+		for _, endpoint := range endpoints {
+			if endpoint.ApplicationID != applicationID {
+				continue
+			}
+			if err = api.DeleteDomainEndpoint(domain.ID, endpoint.ID); err != nil {
+				return err
+			}
+			report("domainEndpoint", endpoint.ID)
+		}
+	}
+	exists, err := api.applicationExists(applicationID)
+	if err != nil || !exists {
+		return err
+	}
+	if err = api.DeleteApplication(applicationID); err != nil {
+		return err
+	}
+	report("application", applicationID)
+	return nil
+}
+
+func (api *Client) applicationExists(id string) (bool, error) {
+	list, err := listAllTyped[Application](api, api.concatUserPath(applicationsPath), &GetApplicationsQuery{Size: maxPageSize})
+	if err != nil {
+		return false, err
+	}
+	for _, application := range list {
+		if application.ID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ApplicationResult is result of creating an application by CreateApplications()
+type ApplicationResult struct {
+	// ID of created application (empty if it was not created)
+	ID    string
+	Error error
+}
+
+// CreateApplications creates many applications (like CreateApplication(), requests are made in parallel)
+// Failed items don't stop the batch, items failed with rate limit errors are retried.
+// It returns list of results in order of configs and *BatchError (keyed by index of config) if some applications were not created
+// example: results, err := api.CreateApplications([]*bandwidth.ApplicationData{&bandwidth.ApplicationData{Name: "Brand 1"}, &bandwidth.ApplicationData{Name: "Brand 2"}})
+func (api *Client) CreateApplications(configs []*ApplicationData) ([]*ApplicationResult, error) {
+	results := make([]*ApplicationResult, len(configs))
+	errs := api.runBatch(len(configs), func(i int) error {
+		if configs[i] == nil {
+			return errors.New("Missing application data")
+		}
+		id, err := api.CreateApplication(configs[i])
+		results[i] = &ApplicationResult{ID: id}
+		return err
+	})
+	batchErr := &BatchError{Errors: map[string]error{}}
+	for i, err := range errs {
+		if results[i] == nil {
+			results[i] = &ApplicationResult{}
+		}
+		results[i].Error = err
+		if err != nil {
+			results[i].ID = ""
+			batchErr.Errors[strconv.Itoa(i)] = err
+		}
+	}
+	if len(batchErr.Errors) > 0 {
+		return results, batchErr
+	}
+	return results, nil
+}
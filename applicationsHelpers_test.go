@@ -0,0 +1,204 @@
+package bandwidth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func startCallbackServer(handler func(w http.ResponseWriter, r *http.Request)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(handler))
+}
+
+func TestCheckCallbackURL(t *testing.T) {
+	api := getAPI()
+	for _, statusCode := range []int{http.StatusOK, http.StatusNoContent, http.StatusFound, http.StatusUnauthorized} {
+		code := statusCode
+		server := startCallbackServer(func(w http.ResponseWriter, r *http.Request) {
+			expect(t, r.Method, http.MethodHead)
+			if code == http.StatusFound {
+				w.Header().Set("Location", "http://localhost/other")
+			}
+			w.WriteHeader(code)
+		})
+		err := api.CheckCallbackURL(context.Background(), server.URL)
+		server.Close()
+		if err != nil {
+			t.Errorf("Failed call of CheckCallbackURL() for http code %d: %s", code, err.Error())
+		}
+	}
+}
+
+func TestCheckCallbackURLWithGetFallback(t *testing.T) {
+	api := getAPI()
+	methods := []string{}
+	server := startCallbackServer(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	defer server.Close()
+	err := api.CheckCallbackURL(context.Background(), server.URL)
+	if err != nil {
+		t.Error("Failed call of CheckCallbackURL()")
+		return
+	}
+	expect(t, methods, []string{http.MethodHead, http.MethodGet})
+}
+
+func TestCheckCallbackURLFail(t *testing.T) {
+	api := getAPI()
+	server := startCallbackServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	defer server.Close()
+	err := api.CheckCallbackURL(context.Background(), server.URL)
+	if err == nil {
+		t.Error("Should fail here")
+	}
+	err = api.CheckCallbackURL(context.Background(), "invalid:/\n/ url = ")
+	if err == nil {
+		t.Error("Should fail here")
+	}
+}
+
+func TestCheckCallbackURLWithTimeout(t *testing.T) {
+	api := getAPI()
+	api.CallbackCheckTimeout = 10 * time.Millisecond
+	done := make(chan struct{})
+	server := startCallbackServer(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	})
+	defer server.Close()
+	defer close(done)
+	err := api.CheckCallbackURL(context.Background(), server.URL)
+	if err == nil {
+		t.Error("Should fail here")
+	}
+}
+
+func TestDeprovisionApplication(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/phoneNumbers?applicationId=123&size=1000",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "n1", "applicationId": "123"}]`}, RequestHandler{
+		PathAndQuery: "/v1/users/userId/phoneNumbers/n1",
+		Method:       http.MethodDelete}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/domains?size=100",
+		Method:        http.MethodGet,
+		HeadersToSend: map[string]string{"Link": `<https://api.catapult.inetwork.com/v1/users/userId/domains?page=1&size=100>; rel="next"`},
+		ContentToSend: `[{"id": "d1"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/domains?page=1&size=100",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "d2"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/domains/d1/endpoints?size=100",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "e1", "applicationId": "123"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/domains/d2/endpoints?size=100",
+		Method:        http.MethodGet,
+		HeadersToSend: map[string]string{"Link": `<https://api.catapult.inetwork.com/v1/users/userId/domains/d2/endpoints?page=1&size=100>; rel="next"`},
+		ContentToSend: `[{"id": "e3", "applicationId": "456"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/domains/d2/endpoints?page=1&size=100",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "e2", "applicationId": "123"}]`}, RequestHandler{
+		PathAndQuery: "/v1/users/userId/domains/d1/endpoints/e1",
+		Method:       http.MethodDelete}, RequestHandler{
+		PathAndQuery: "/v1/users/userId/domains/d2/endpoints/e2",
+		Method:       http.MethodDelete}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/applications?size=1000",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "456"}, {"id": "123"}]`}, RequestHandler{
+		PathAndQuery: "/v1/users/userId/applications/123",
+		Method:       http.MethodDelete}})
+	defer server.Close()
+	steps := []string{}
+	err := api.DeprovisionApplication("123", func(resource, id string) {
+		steps = append(steps, resource+":"+id)
+	})
+	if err != nil {
+		t.Errorf("Failed call of DeprovisionApplication() %s", err.Error())
+		return
+	}
+	expect(t, steps, []string{"phoneNumber:n1", "domainEndpoint:e1", "domainEndpoint:e2", "application:123"})
+}
+
+func TestDeprovisionApplicationAlreadyRemoved(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/phoneNumbers?applicationId=123&size=1000",
+		Method:        http.MethodGet,
+		ContentToSend: `[]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/domains?size=100",
+		Method:        http.MethodGet,
+		ContentToSend: `[]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/applications?size=1000",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "456"}]`}})
+	defer server.Close()
+	steps := 0
+	err := api.DeprovisionApplication("123", func(resource, id string) { steps++ })
+	if err != nil {
+		t.Errorf("Failed call of DeprovisionApplication() %s", err.Error())
+		return
+	}
+	expect(t, steps, 0)
+}
+
+func TestDeprovisionApplicationFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/phoneNumbers?applicationId=123&size=1000",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "n1", "applicationId": "123"}]`}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/phoneNumbers/n1",
+		Method:           http.MethodDelete,
+		StatusCodeToSend: http.StatusBadRequest}})
+	defer server.Close()
+	err := api.DeprovisionApplication("123")
+	if err == nil {
+		t.Error("Should fail here")
+	}
+}
+
+func TestCreateApplications(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/applications",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"name":"Brand"}`,
+		HeadersToSend:    map[string]string{"Location": "/v1/users/{userId}/applications/123"}}})
+	defer server.Close()
+	results, err := api.CreateApplications([]*ApplicationData{&ApplicationData{Name: "Brand"}, nil, &ApplicationData{Name: "Brand"}})
+	expect(t, len(results), 3)
+	expect(t, results[0].ID, "123")
+	expectNil(t, results[0].Error)
+	expect(t, results[1].ID, "")
+	if results[1].Error == nil {
+		t.Error("Should contain error for missing data")
+	}
+	expect(t, results[2].ID, "123")
+	batchErr, ok := err.(*BatchError)
+	if !ok {
+		t.Fatal("Should return BatchError")
+	}
+	expect(t, len(batchErr.Errors), 1)
+	if batchErr.Errors["1"] == nil {
+		t.Error("Should contain error by index")
+	}
+	results, err = api.CreateApplications([]*ApplicationData{&ApplicationData{Name: "Brand"}})
+	expectNil(t, err)
+	expect(t, results[0].ID, "123")
+}
+
+func TestCreateApplicationsFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/applications",
+		Method:           http.MethodPost,
+		StatusCodeToSend: http.StatusBadRequest}})
+	defer server.Close()
+	results, err := api.CreateApplications([]*ApplicationData{&ApplicationData{Name: "Brand"}})
+	if err == nil {
+		t.Fatal("Should fail")
+	}
+	expect(t, results[0].ID, "")
+}
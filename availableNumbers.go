@@ -18,6 +18,13 @@ const (
 
 const availableNumbersPath = "availableNumbers"
 
+func (t AvailableNumberType) validate() error {
+	if t != AvailableNumberTypeLocal && t != AvailableNumberTypeTollFree {
+		return fmt.Errorf("Unsupported number type %q. Please use bandwidth.AvailableNumberTypeLocal or bandwidth.AvailableNumberTypeTollFree", t)
+	}
+	return nil
+}
+
 // AvailableNumber struct
 type AvailableNumber struct {
 	Number         string  `json:"number"`
@@ -31,23 +38,25 @@ type AvailableNumber struct {
 
 // GetAvailableNumberQuery is  query parameters of GetAvailableNumbers() and GetAndOrderAvailableNumbers()
 type GetAvailableNumberQuery struct {
-	City               string
-	State              string
-	Zip                string
-	AreaCode           string
-	LocalNumber        string
-	InLocalCallingArea bool
+	City        string
+	State       string
+	Zip         string
+	AreaCode    string
+	LocalNumber string
+	// InLocalCallingArea is optional (use bandwidth.Bool(), nil means the API default)
+	InLocalCallingArea *bool
 	Quantity           int
 	Pattern            string
+	// QueryParams are raw query parameters (see QueryParams type)
+	QueryParams QueryParams
 }
 
 // GetAvailableNumbers looks for available numbers
 func (api *Client) GetAvailableNumbers(numberType AvailableNumberType, query *GetAvailableNumberQuery) ([]*AvailableNumber, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s", availableNumbersPath, numberType), &[]*AvailableNumber{}, query)
-	if err != nil {
+	if err := numberType.validate(); err != nil {
 		return nil, err
 	}
-	return *(result.(*[]*AvailableNumber)), nil
+	return listTyped[AvailableNumber](api, fmt.Sprintf("%s/%s", availableNumbersPath, numberType), query)
 }
 
 // OrderedNumber struct
@@ -61,6 +70,9 @@ type OrderedNumber struct {
 
 // GetAndOrderAvailableNumbers looks for available numbers and orders them
 func (api *Client) GetAndOrderAvailableNumbers(numberType AvailableNumberType, query *GetAvailableNumberQuery) ([]*OrderedNumber, error) {
+	if err := numberType.validate(); err != nil {
+		return nil, err
+	}
 	path := fmt.Sprintf("%s/%s", availableNumbersPath, numberType)
 	result, _, err := api.makeRequest(http.MethodPost, path, &[]*OrderedNumber{}, query, true)
 	if err != nil {
@@ -41,6 +41,23 @@ func TestGetAvailableNumbers(t *testing.T) {
 	expect(t, len(result), 2)
 }
 
+func TestGetAvailableNumbersWithOptionalFilter(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/availableNumbers/local?areaCode=919&inLocalCallingArea=false",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"number": "+19195551212"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/availableNumbers/local?areaCode=919",
+		Method:        http.MethodGet,
+		ContentToSend: `[]`}})
+	defer server.Close()
+	result, err := api.GetAvailableNumbers(AvailableNumberTypeLocal, &GetAvailableNumberQuery{AreaCode: "919", InLocalCallingArea: Bool(false)})
+	expectNil(t, err)
+	expect(t, len(result), 1)
+	result, err = api.GetAvailableNumbers(AvailableNumberTypeLocal, &GetAvailableNumberQuery{AreaCode: "919"})
+	expectNil(t, err)
+	expect(t, len(result), 0)
+}
+
 func TestGetAvailableNumbersFail(t *testing.T) {
 	server, api := startMockServer(t, []RequestHandler{RequestHandler{
 		PathAndQuery:     "/v1/availableNumbers/local?city=Cary&state=NC",
@@ -97,3 +114,13 @@ func TestGetAndOrderAvailableNumbersFail(t *testing.T) {
 			City:  "Cary"})
 	})
 }
+
+func TestAvailableNumbersWithUnsupportedType(t *testing.T) {
+	api := getAPI()
+	shouldFail(t, func() (interface{}, error) {
+		return api.GetAvailableNumbers("mobile", &GetAvailableNumberQuery{AreaCode: "910"})
+	})
+	shouldFail(t, func() (interface{}, error) {
+		return api.GetAndOrderAvailableNumbers("", &GetAvailableNumberQuery{AreaCode: "910"})
+	})
+}
@@ -0,0 +1,76 @@
+package bandwidth
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultBatchConcurrency is count of parallel requests made by batch methods when Client.BatchConcurrency is not set
+const DefaultBatchConcurrency = 4
+
+// attempts to make per batch item when API returns RateLimitError (if the client doesn't retry such requests itself)
+const batchRateLimitAttempts = 3
+
+// BatchError is returned by batch methods when some of the items failed
+type BatchError struct {
+	// Errors contains error per failed item (by id)
+	Errors map[string]error
+}
+
+func (e *BatchError) Error() string {
+	keys := make([]string, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	messages := make([]string, 0, len(keys))
+	for _, key := range keys {
+		messages = append(messages, fmt.Sprintf("%s: %s", key, e.Errors[key].Error()))
+	}
+	return fmt.Sprintf("BatchError: %d item(s) failed (%s)", len(keys), strings.Join(messages, "; "))
+}
+
+// runBatch calls action for each index in [0, count) using no more than Client.BatchConcurrency goroutines
+// Items failed with RateLimitError are retried after the limit reset unless the client already retries
+// such requests (see WithRateLimitRetries()), so retries are not multiplied.
+// It returns list of errors (by index)
+func (api *Client) runBatch(count int, action func(i int) error) []error {
+	concurrency := api.BatchConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+	attempts := batchRateLimitAttempts
+	if api.MaxRateLimitRetries > 0 {
+		attempts = 1
+	}
+	errs := make([]error, count)
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency && w < count; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				for attempt := 1; ; attempt++ {
+					errs[i] = action(i)
+					rateLimitErr, ok := errs[i].(*RateLimitError)
+					if !ok || attempt >= attempts {
+						break
+					}
+					if err := sleepContext(api.context(), rateLimitErr.RetryAfter()+rateLimitRetryJitter()); err != nil {
+						errs[i] = err
+						break
+					}
+				}
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,96 @@
+package bandwidth
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBatch(t *testing.T) {
+	api := getAPI()
+	api.BatchConcurrency = 2
+	var active, maxActive int32
+	errs := api.runBatch(10, func(i int) error {
+		n := atomic.AddInt32(&active, 1)
+		for {
+			m := atomic.LoadInt32(&maxActive)
+			if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&active, -1)
+		if i == 3 {
+			return errors.New("error")
+		}
+		return nil
+	})
+	expect(t, len(errs), 10)
+	for i, err := range errs {
+		if (err != nil) != (i == 3) {
+			t.Errorf("Unexpected error %v for item %d", err, i)
+		}
+	}
+	if atomic.LoadInt32(&maxActive) > 2 {
+		t.Errorf("Too many parallel actions: %d", maxActive)
+	}
+}
+
+func TestRunBatchWithRateLimit(t *testing.T) {
+	sleeps := 0
+	sleepContext = func(ctx context.Context, d time.Duration) error {
+		sleeps++
+		return nil
+	}
+	defer func() { sleepContext = sleepContextDefault }()
+	api := getAPI()
+	calls := 0
+	errs := api.runBatch(1, func(i int) error {
+		calls++
+		if calls < 2 {
+			return &RateLimitError{Reset: time.Now()}
+		}
+		return nil
+	})
+	expectNil(t, errs[0])
+	expect(t, calls, 2)
+	expect(t, sleeps, 1)
+	calls = 0
+	errs = api.runBatch(1, func(i int) error {
+		calls++
+		return &RateLimitError{Reset: time.Now()}
+	})
+	expect(t, calls, batchRateLimitAttempts)
+	if _, ok := errs[0].(*RateLimitError); !ok {
+		t.Error("Should return RateLimitError")
+	}
+	// the client retries by itself
+	api.MaxRateLimitRetries = 2
+	calls, sleeps = 0, 0
+	api.runBatch(1, func(i int) error {
+		calls++
+		return &RateLimitError{Reset: time.Now()}
+	})
+	expect(t, calls, 1)
+	expect(t, sleeps, 0)
+}
+
+func TestRunBatchWithRateLimitAndCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	api := getAPI().WithContext(ctx)
+	calls := 0
+	errs := api.runBatch(1, func(i int) error {
+		calls++
+		return &RateLimitError{Reset: time.Now().Add(time.Hour)}
+	})
+	expect(t, calls, 1)
+	expect(t, errs[0], context.Canceled)
+}
+
+func TestBatchError(t *testing.T) {
+	err := &BatchError{Errors: map[string]error{"b": errors.New("error2"), "a": errors.New("error1")}}
+	expect(t, err.Error(), "BatchError: 2 item(s) failed (a: error1; b: error2)")
+}
@@ -1,6 +1,7 @@
 package bandwidth
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -22,20 +23,30 @@ type Bridge struct {
 type GetBridgesQuery struct {
 	Page int
 	Size int
+	// QueryParams are raw query parameters (see QueryParams type)
+	QueryParams QueryParams
 }
 
 // GetBridges returns list of previous bridges
 // It returns list of Bridge instances or error
 func (api *Client) GetBridges(query ...*GetBridgesQuery) ([]*Bridge, error) {
+	list, _, err := api.GetBridgesPage(query...)
+	return list, err
+}
+
+// GetBridgesPage returns a page of previous bridges like GetBridges() with pagination data of the page
+// It returns list of Bridge instances, Pagination instance or error
+// example: list, pagination, err := api.GetBridgesPage(&bandwidth.GetBridgesQuery{Page: 1})
+func (api *Client) GetBridgesPage(query ...*GetBridgesQuery) ([]*Bridge, *Pagination, error) {
 	var options *GetBridgesQuery
 	if len(query) > 0 {
 		options = query[0]
 	}
-	result, _, err := api.makeRequest(http.MethodGet, api.concatUserPath(bridgesPath), &[]*Bridge{}, options)
+	result, pagination, err := api.getListPage(api.concatUserPath(bridgesPath), &[]*Bridge{}, options)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return *(result.(*[]*Bridge)), nil
+	return *(result.(*[]*Bridge)), pagination, nil
 }
 
 // BridgeData struct
@@ -47,21 +58,17 @@ type BridgeData struct {
 // CreateBridge creates a bridge
 // It returns ID of created bridge
 func (api *Client) CreateBridge(data *BridgeData) (string, error) {
-	_, headers, err := api.makeRequest(http.MethodPost, api.concatUserPath(bridgesPath), nil, data)
+	result, headers, err := api.makeRequest(http.MethodPost, api.concatUserPath(bridgesPath), nil, data)
 	if err != nil {
 		return "", err
 	}
-	return getIDFromLocationHeader(headers), nil
+	return getCreatedID(result, headers)
 }
 
 // GetBridge returns a bridge
 // It returns Bridge instance fo found bridge or error
 func (api *Client) GetBridge(id string) (*Bridge, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s", api.concatUserPath(bridgesPath), id), &Bridge{})
-	if err != nil {
-		return nil, err
-	}
-	return result.(*Bridge), nil
+	return getTyped[Bridge](api, fmt.Sprintf("%s/%s", api.concatUserPath(bridgesPath), id), nil)
 }
 
 // UpdateBridge adds one or two calls in a bridge and also puts the bridge on hold/unhold
@@ -82,9 +89,21 @@ type PlayAudioData struct {
 	Tag         string `json:"tag,omitempty"`
 }
 
+func (d *PlayAudioData) validate() error {
+	if d.FileURL != "" && d.Sentence != "" {
+		return errors.New("Only one of FileURL and Sentence can be set")
+	}
+	return validateVoice(d.Voice, d.Locale, d.Gender)
+}
+
 // PlayAudioToBridge plays an audio or speak a sentence in a bridge
 // It returns error object
 func (api *Client) PlayAudioToBridge(id string, data *PlayAudioData) error {
+	if data != nil {
+		if err := data.validate(); err != nil {
+			return err
+		}
+	}
 	_, _, err := api.makeRequest(http.MethodPost, fmt.Sprintf("%s/%s/%s", api.concatUserPath(bridgesPath), id, "audio"), nil, data)
 	return err
 }
@@ -92,9 +111,5 @@ func (api *Client) PlayAudioToBridge(id string, data *PlayAudioData) error {
 // GetBridgeCalls returns bridge's calls
 // It returns list of Call instances or error
 func (api *Client) GetBridgeCalls(id string) ([]*Call, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s/%s", api.concatUserPath(bridgesPath), id, "calls"), &[]*Call{})
-	if err != nil {
-		return nil, err
-	}
-	return *(result.(*[]*Call)), nil
+	return listTyped[Call](api, fmt.Sprintf("%s/%s/%s", api.concatUserPath(bridgesPath), id, "calls"), nil)
 }
@@ -97,6 +97,9 @@ func TestGetBridge(t *testing.T) {
 		return
 	}
 	expect(t, result.ID, "{bridgeId}")
+	expect(t, result.CreatedTime, "2013-04-22T13:58:30.121Z")
+	expect(t, result.ActivatedTime, "2013-04-22T13:58:30.122Z")
+	expect(t, result.CompletedTime, "2013-04-22T13:59:30.122Z")
 }
 
 func TestGetBridgeFail(t *testing.T) {
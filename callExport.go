@@ -0,0 +1,75 @@
+package bandwidth
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// CallExportVersion is version of CallExport schema
+const CallExportVersion = 1
+
+// CallExport is JSON document written by ExportCall()
+// Schema (version 1):
+// {"version": 1, "call": {Call}, "events": [{CallEvent}], "recordings": [{Recording}], "transcriptions": [{Transcription}]}
+// Lists are always present (empty if there are no items).
+type CallExport struct {
+	Version        int              `json:"version"`
+	Call           *Call            `json:"call"`
+	Events         []*CallEvent     `json:"events"`
+	Recordings     []*Recording     `json:"recordings"`
+	Transcriptions []*Transcription `json:"transcriptions"`
+}
+
+// ExportCall writes the call data, its events, recordings and transcriptions metadata to w as single JSON document (see CallExport)
+// Sub-resources are requested in parallel.
+// It returns error object
+// example: api.ExportCall("callId", file)
+func (api *Client) ExportCall(callID string, w io.Writer) error {
+	export := &CallExport{Version: CallExportVersion}
+	var wg sync.WaitGroup
+	errs := make([]error, 4)
+	actions := []func() error{
+		func() (err error) {
+			export.Call, err = api.GetCall(callID)
+			return
+		},
+		func() (err error) {
+			export.Events, err = api.GetCallEvents(callID)
+			return
+		},
+		func() (err error) {
+			export.Recordings, err = api.GetCallRecordings(callID)
+			return
+		},
+		func() (err error) {
+			export.Transcriptions, err = api.GetCallTranscriptions(callID)
+			return
+		},
+	}
+	for i, action := range actions {
+		wg.Add(1)
+		go func(i int, action func() error) {
+			defer wg.Done()
+			errs[i] = action()
+		}(i, action)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	if export.Events == nil {
+		export.Events = []*CallEvent{}
+	}
+	if export.Recordings == nil {
+		export.Recordings = []*Recording{}
+	}
+	if export.Transcriptions == nil {
+		export.Transcriptions = []*Transcription{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(export)
+}
@@ -0,0 +1,63 @@
+package bandwidth
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestExportCall(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/123",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "123", "state": "completed"}`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/123/events",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "e1", "name": "create"}, {"id": "e2", "name": "hangup"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/123/recordings",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "r1", "state": "complete"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/123/transcriptions",
+		Method:        http.MethodGet,
+		ContentToSend: `[]`}})
+	defer server.Close()
+	buffer := &bytes.Buffer{}
+	err := api.ExportCall("123", buffer)
+	if err != nil {
+		t.Error("Failed call of ExportCall()")
+		return
+	}
+	export := map[string]interface{}{}
+	if err = json.Unmarshal(buffer.Bytes(), &export); err != nil {
+		t.Fatal(err)
+	}
+	expect(t, export["version"], float64(1))
+	expect(t, export["call"].(map[string]interface{})["id"], "123")
+	expect(t, len(export["events"].([]interface{})), 2)
+	expect(t, len(export["recordings"].([]interface{})), 1)
+	expect(t, len(export["transcriptions"].([]interface{})), 0)
+}
+
+func TestExportCallFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/123",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "123", "state": "completed"}`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/123/events",
+		Method:        http.MethodGet,
+		ContentToSend: `[]`}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls/123/recordings",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusBadRequest}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/123/transcriptions",
+		Method:        http.MethodGet,
+		ContentToSend: `[]`}})
+	defer server.Close()
+	buffer := &bytes.Buffer{}
+	err := api.ExportCall("123", buffer)
+	if err == nil {
+		t.Error("Should fail here")
+	}
+	expect(t, buffer.Len(), 0)
+}
@@ -0,0 +1,113 @@
+package bandwidth
+
+import (
+	"sort"
+	"time"
+)
+
+// CallTimelineEvent is an event of the call with parsed time
+type CallTimelineEvent struct {
+	ID   string
+	Name string
+	Time time.Time
+}
+
+// CallTimeline is ordered list of call events with computed durations
+type CallTimeline struct {
+	Call   *Call
+	Events []*CallTimelineEvent
+
+	// RingDuration is time from call creation to answer
+	RingDuration time.Duration
+	// TalkDuration is time from answer to hang up (including hold time)
+	TalkDuration time.Duration
+	// HoldDuration is total time between "hold" and "unhold" events
+	HoldDuration time.Duration
+}
+
+// ReconstructCall builds timeline of the call from the call data and its events
+// Duplicated events (same id) are ignored, events are ordered by time.
+// Ring and talk durations are computed from call's startTime/activeTime/endTime
+// (with fallback to "answer" and "hangup" events), hold duration from "hold"/"unhold" events.
+// It returns CallTimeline instance or error
+func (api *Client) ReconstructCall(callID string) (*CallTimeline, error) {
+	call, err := api.GetCall(callID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := api.GetCallEvents(callID)
+	if err != nil {
+		return nil, err
+	}
+	timeline := &CallTimeline{Call: call, Events: make([]*CallTimelineEvent, 0, len(events))}
+	seen := make(map[string]bool, len(events))
+	for _, event := range events {
+		if event.ID != "" {
+			if seen[event.ID] {
+				continue
+			}
+			seen[event.ID] = true
+		}
+		timeline.Events = append(timeline.Events, &CallTimelineEvent{ID: event.ID, Name: event.Name, Time: parseTime(event.Time)})
+	}
+	// events without valid time are moved to the end
+	sort.SliceStable(timeline.Events, func(i, j int) bool {
+		a, b := timeline.Events[i].Time, timeline.Events[j].Time
+		if a.IsZero() || b.IsZero() {
+			return !a.IsZero()
+		}
+		return a.Before(b)
+	})
+	timeline.computeDurations()
+	return timeline, nil
+}
+
+func (t *CallTimeline) findEventTime(name string) time.Time {
+	for _, event := range t.Events {
+		if event.Name == name && !event.Time.IsZero() {
+			return event.Time
+		}
+	}
+	return time.Time{}
+}
+
+func (t *CallTimeline) computeDurations() {
+	start := parseTime(t.Call.StartTime)
+	if start.IsZero() {
+		start = t.findEventTime("create")
+	}
+	active := parseTime(t.Call.ActiveTime)
+	if active.IsZero() {
+		active = t.findEventTime("answer")
+	}
+	end := parseTime(t.Call.EndTime)
+	if end.IsZero() {
+		end = t.findEventTime("hangup")
+	}
+	if !start.IsZero() && !active.IsZero() && active.After(start) {
+		t.RingDuration = active.Sub(start)
+	}
+	if !active.IsZero() && !end.IsZero() && end.After(active) {
+		t.TalkDuration = end.Sub(active)
+	}
+	var holdStart time.Time
+	for _, event := range t.Events {
+		if event.Time.IsZero() {
+			continue
+		}
+		switch event.Name {
+		case "hold":
+			if holdStart.IsZero() {
+				holdStart = event.Time
+			}
+		case "unhold":
+			if !holdStart.IsZero() {
+				t.HoldDuration += event.Time.Sub(holdStart)
+				holdStart = time.Time{}
+			}
+		}
+	}
+	if !holdStart.IsZero() && !end.IsZero() && end.After(holdStart) {
+		t.HoldDuration += end.Sub(holdStart)
+	}
+}
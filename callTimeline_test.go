@@ -0,0 +1,83 @@
+package bandwidth
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestReconstructCall(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: "/v1/users/userId/calls/123",
+		Method:       http.MethodGet,
+		ContentToSend: `{
+			"id": "123",
+			"state": "completed",
+			"startTime": "2013-02-08T13:15:00Z",
+			"activeTime": "2013-02-08T13:15:10Z",
+			"endTime": "2013-02-08T13:16:10Z"
+		}`}, RequestHandler{
+		PathAndQuery: "/v1/users/userId/calls/123/events",
+		Method:       http.MethodGet,
+		ContentToSend: `[
+			{"id": "e5", "name": "hangup", "time": "2013-02-08T13:16:10Z"},
+			{"id": "e1", "name": "create", "time": "2013-02-08T13:15:00Z"},
+			{"id": "e3", "name": "hold", "time": "2013-02-08T13:15:20Z"},
+			{"id": "e2", "name": "answer", "time": "2013-02-08T13:15:10Z"},
+			{"id": "e3", "name": "hold", "time": "2013-02-08T13:15:20Z"},
+			{"id": "e4", "name": "unhold", "time": "2013-02-08T13:15:50Z"}
+		]`}})
+	defer server.Close()
+	timeline, err := api.ReconstructCall("123")
+	if err != nil {
+		t.Error("Failed call of ReconstructCall()")
+		return
+	}
+	names := []string{}
+	for _, event := range timeline.Events {
+		names = append(names, event.Name)
+	}
+	expect(t, names, []string{"create", "answer", "hold", "unhold", "hangup"})
+	expect(t, timeline.RingDuration, 10*time.Second)
+	expect(t, timeline.TalkDuration, time.Minute)
+	expect(t, timeline.HoldDuration, 30*time.Second)
+}
+
+func TestReconstructCallWithEventsOnly(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/123",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "123", "state": "completed"}`}, RequestHandler{
+		PathAndQuery: "/v1/users/userId/calls/123/events",
+		Method:       http.MethodGet,
+		ContentToSend: `[
+			{"id": "e1", "name": "create", "time": "2013-02-08T13:15:00Z"},
+			{"id": "e2", "name": "answer", "time": "2013-02-08T13:15:05Z"},
+			{"id": "e3", "name": "hold", "time": "2013-02-08T13:15:10Z"},
+			{"id": "e4", "name": "speak", "time": "invalid"},
+			{"id": "e5", "name": "hangup", "time": "2013-02-08T13:15:25Z"}
+		]`}})
+	defer server.Close()
+	timeline, err := api.ReconstructCall("123")
+	if err != nil {
+		t.Error("Failed call of ReconstructCall()")
+		return
+	}
+	expect(t, len(timeline.Events), 5)
+	expect(t, timeline.Events[4].Name, "speak")
+	expect(t, timeline.RingDuration, 5*time.Second)
+	expect(t, timeline.TalkDuration, 20*time.Second)
+	expect(t, timeline.HoldDuration, 15*time.Second)
+}
+
+func TestReconstructCallFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/123",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "123"}`}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls/123/events",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusBadRequest}})
+	defer server.Close()
+	shouldFail(t, func() (interface{}, error) { return api.ReconstructCall("123") })
+}
@@ -1,12 +1,35 @@
 package bandwidth
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 const callsPath = "calls"
 
+// Caller id presentation of CreateCallData (messages have no such option, their sender is always From number)
+const (
+	// CallerIDPresentationAllowed shows caller id of outbound call to the callee
+	CallerIDPresentationAllowed = "allowed"
+
+	// CallerIDPresentationRestricted withholds caller id of outbound call (anonymous call)
+	CallerIDPresentationRestricted = "restricted"
+)
+
+// Recording states of a call
+const (
+	// RecordingStateRecording means that the call is being recorded
+	RecordingStateRecording = "recording"
+
+	// RecordingStatePaused means that recording of the call is paused
+	RecordingStatePaused = "paused"
+
+	// RecordingStateComplete means that recording of the call is finished
+	RecordingStateComplete = "complete"
+)
+
 // Call struct
 type Call struct {
 	ID                   string            `json:"id"`
@@ -19,6 +42,7 @@ type Call struct {
 	RecordingFileFormat  string            `json:"recordingFileFormat"`
 	RecordingEnabled     bool              `json:"recordingEnabled"`
 	RecordingMaxDuration int               `json:"recordingMaxDuration"`
+	RecordingState       string            `json:"recordingState"`
 	State                string            `json:"state"`
 	To                   string            `json:"to"`
 	TranscriptionEnabled bool              `json:"transcriptionEnabled"`
@@ -32,6 +56,19 @@ type Call struct {
 	CallbackHTTPMethod   string            `json:"callbackHttpMethod"`
 	FallbackURL          string            `json:"fallbackUrl"`
 	CallbackTimeout      int               `json:"callbackTimeout"`
+	Diversion            *CallDiversion    `json:"diversion"`
+}
+
+// CallDiversion contains data of SIP Diversion header of forwarded inbound call
+type CallDiversion struct {
+	// OrigTo is originally dialed number
+	OrigTo  string `json:"origTo"`
+	Reason  string `json:"reason"`
+	Privacy string `json:"privacy"`
+	Screen  string `json:"screen"`
+	Counter string `json:"counter"`
+	Limit   string `json:"limit"`
+	Unknown string `json:"unknown"`
 }
 
 // GetCallsQuery is optional parameters of GetCalls()
@@ -43,20 +80,45 @@ type GetCallsQuery struct {
 	From         string
 	To           string
 	SortOrder    string
+	// QueryParams are raw query parameters (see QueryParams type)
+	QueryParams QueryParams
 }
 
 // GetCalls returns list of previous calls that were made or received
 // It returns list of Call instances or error
 func (api *Client) GetCalls(query ...*GetCallsQuery) ([]*Call, error) {
+	list, _, err := api.GetCallsPage(query...)
+	return list, err
+}
+
+// GetCallsPage returns a page of previous calls like GetCalls() with pagination data of the page
+// It returns list of Call instances, Pagination instance or error
+// example: list, pagination, err := api.GetCallsPage(&bandwidth.GetCallsQuery{Page: 1})
+func (api *Client) GetCallsPage(query ...*GetCallsQuery) ([]*Call, *Pagination, error) {
 	var options *GetCallsQuery
 	if len(query) > 0 {
 		options = query[0]
 	}
-	result, _, err := api.makeRequest(http.MethodGet, api.concatUserPath(callsPath), &[]*Call{}, options)
+	result, pagination, err := api.getListPage(api.concatUserPath(callsPath), &[]*Call{}, options)
 	if err != nil {
+		return nil, nil, err
+	}
+	return *(result.(*[]*Call)), pagination, nil
+}
+
+// GetAllCalls returns all calls matched to the query (requesting pages one by one while the API returns next page links)
+// It returns list of Call instances or error
+// example: calls, err := api.GetAllCalls(&bandwidth.GetCallsQuery{From: "+19195551212", Size: 1000})
+func (api *Client) GetAllCalls(query ...*GetCallsQuery) ([]*Call, error) {
+	var options *GetCallsQuery
+	if len(query) > 0 {
+		options = query[0]
+	}
+	list := []*Call{}
+	if err := api.getAllPages(api.concatUserPath(callsPath), options, &list); err != nil {
 		return nil, err
 	}
-	return *(result.(*[]*Call)), nil
+	return list, nil
 }
 
 // CreateCallData struct
@@ -77,26 +139,66 @@ type CreateCallData struct {
 	FallbackURL          string            `json:"fallbackUrl,omitempty"`
 	CallbackTimeout      int               `json:"callbackTimeout,omitempty"`
 	CallTimeout          int               `json:"callTimeout,omitempty"`
+	CallerIDPresentation string            `json:"callerIdPresentation,omitempty"`
+}
+
+// normalizeDestination validates SIP URI (like sip:user@domain) in To of the call and normalizes phone numbers to E.164
+// Values which are not recognized as phone numbers are left for the API to validate.
+// It returns data with normalized To (a copy if To was changed) or error
+func (d *CreateCallData) normalizeDestination() (*CreateCallData, error) {
+	to, _, err := ParseNumber(d.To)
+	if err != nil {
+		if isSIPURI(d.To) {
+			return nil, err
+		}
+		return d, nil
+	}
+	if to == d.To {
+		return d, nil
+	}
+	normalized := *d
+	normalized.To = to
+	return &normalized, nil
+}
+
+func (d *CreateCallData) validate() error {
+	if err := validateSipHeaders(d.SipHeaders); err != nil {
+		return err
+	}
+	switch d.CallerIDPresentation {
+	case "", CallerIDPresentationAllowed, CallerIDPresentationRestricted:
+		return nil
+	}
+	return errors.New("CallerIDPresentation should be \"allowed\" or \"restricted\"")
 }
 
 // CreateCall creates an outbound phone call
 // It returns ID of created call
 func (api *Client) CreateCall(data *CreateCallData) (string, error) {
-	_, headers, err := api.makeRequest(http.MethodPost, api.concatUserPath(callsPath), nil, data)
+	if data != nil {
+		if err := data.validate(); err != nil {
+			return "", err
+		}
+		normalized, err := data.normalizeDestination()
+		if err != nil {
+			return "", err
+		}
+		data = normalized
+		if err := api.checkAllowedNumbers(data.To); err != nil {
+			return "", err
+		}
+	}
+	result, headers, err := api.makeRequest(http.MethodPost, api.concatUserPath(callsPath), nil, data)
 	if err != nil {
 		return "", err
 	}
-	return getIDFromLocationHeader(headers), nil
+	return getCreatedID(result, headers)
 }
 
 // GetCall returns information about a call that was made or received
 // It return Call instance for found call or error
 func (api *Client) GetCall(id string) (*Call, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s", api.concatUserPath(callsPath), id), &Call{})
-	if err != nil {
-		return nil, err
-	}
-	return result.(*Call), nil
+	return getTyped[Call](api, fmt.Sprintf("%s/%s", api.concatUserPath(callsPath), id), nil)
 }
 
 // UpdateCallData struct
@@ -105,16 +207,46 @@ type UpdateCallData struct {
 	TransferTo           string         `json:"transferTo,omitempty"`
 	RecordingEnabled     bool           `json:"recordingEnabled,string,omitempty"`
 	RecordingFileFormat  string         `json:"recordingFileFormat,omitempty"`
+	RecordingState       string         `json:"recordingState,omitempty"`
 	State                string         `json:"state,omitempty"`
 	TranscriptionEnabled bool           `json:"transcriptionEnabled,string,omitempty"`
-	CallbackURL          string         `json:"callbackUrl,omitempty"`
+	CallbackURL          string         `json:"callbackUrl,omitempty" clearable:"true"`
 	WhisperAudio         *PlayAudioData `json:"whisperAudio,omitempty"`
-	Tag                  string         `json:"tag,omitempty"`
+	Tag                  string         `json:"tag,omitempty" clearable:"true"`
+	// SipHeaders are custom SIP headers sent with answer of incoming call
+	SipHeaders map[string]string `json:"sipHeaders,omitempty"`
+	// ClearFields lists Go names of fields which should be cleared (sent as null)
+	// Supported fields: CallbackURL, Tag
+	ClearFields []string `json:"-"`
+}
+
+func validateSipHeaders(headers map[string]string) error {
+	for name := range headers {
+		if strings.TrimSpace(name) == "" {
+			return errors.New("SIP header name can't be empty")
+		}
+	}
+	return nil
 }
 
 // UpdateCall manage an active phone call. E.g. Answer an incoming call, reject an incoming call, turn on / off recording, transfer, hang up
 // It returns error object
 func (api *Client) UpdateCall(id string, changedData *UpdateCallData) (string, error) {
+	if changedData != nil {
+		if err := validateSipHeaders(changedData.SipHeaders); err != nil {
+			return "", err
+		}
+		if changedData.TransferTo != "" {
+			if err := api.checkAllowedNumbers(changedData.TransferTo); err != nil {
+				return "", err
+			}
+		}
+		if changedData.WhisperAudio != nil {
+			if err := changedData.WhisperAudio.validate(); err != nil {
+				return "", err
+			}
+		}
+	}
 	_, headers, err := api.makeRequest(http.MethodPost, fmt.Sprintf("%s/%s", api.concatUserPath(callsPath), id), nil, changedData)
 	return getIDFromLocationHeader(headers), err
 }
@@ -122,6 +254,11 @@ func (api *Client) UpdateCall(id string, changedData *UpdateCallData) (string, e
 // PlayAudioToCall plays an audio or speak a sentence in a call
 // It returns error object
 func (api *Client) PlayAudioToCall(id string, data *PlayAudioData) error {
+	if data != nil {
+		if err := data.validate(); err != nil {
+			return err
+		}
+	}
 	_, _, err := api.makeRequest(http.MethodPost, fmt.Sprintf("%s/%s/%s", api.concatUserPath(callsPath), id, "audio"), nil, data)
 	return err
 }
@@ -152,44 +289,41 @@ type CallEvent struct {
 	Name string `json:"name"`
 }
 
+// Names of call events of system imposed timeouts (they are also event types of callback events)
+const (
+	// CallEventTimeout is sent when outgoing call was not answered in time (see CreateCallData.CallTimeout)
+	CallEventTimeout = "timeout"
+	// CallEventMaxDuration is sent when call was hung up because it reached max duration
+	CallEventMaxDuration = "maxDuration"
+)
+
+// IsTimeout returns true if the call was ended by the system (not answered in time or reached max duration) instead of hang up by a party
+func (e *CallEvent) IsTimeout() bool {
+	return e.Name == CallEventTimeout || e.Name == CallEventMaxDuration
+}
+
 // GetCallEvents returns  the list of call events for a call
 // It returns list of CallEvent instances or error
 func (api *Client) GetCallEvents(id string) ([]*CallEvent, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s/%s", api.concatUserPath(callsPath), id, "events"), &[]*CallEvent{})
-	if err != nil {
-		return nil, err
-	}
-	return *(result.(*[]*CallEvent)), nil
+	return listTyped[CallEvent](api, fmt.Sprintf("%s/%s/%s", api.concatUserPath(callsPath), id, "events"), nil)
 }
 
 // GetCallEvent returns information about one call event
 // It returns CallEvent instance for found event or error
 func (api *Client) GetCallEvent(id string, eventID string) (*CallEvent, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s/%s/%s", api.concatUserPath(callsPath), id, "events", eventID), &CallEvent{})
-	if err != nil {
-		return nil, err
-	}
-	return result.(*CallEvent), nil
+	return getTyped[CallEvent](api, fmt.Sprintf("%s/%s/%s/%s", api.concatUserPath(callsPath), id, "events", eventID), nil)
 }
 
 // GetCallRecordings returns  all recordings related to the call
 // It return list of Recording instances or error
 func (api *Client) GetCallRecordings(id string) ([]*Recording, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s/%s", api.concatUserPath(callsPath), id, "recordings"), &[]*Recording{})
-	if err != nil {
-		return nil, err
-	}
-	return *(result.(*[]*Recording)), nil
+	return listTyped[Recording](api, fmt.Sprintf("%s/%s/%s", api.concatUserPath(callsPath), id, "recordings"), nil)
 }
 
 // GetCallTranscriptions returns  all transcriptions  related to the call
 // It return list of Transcription instances or error
 func (api *Client) GetCallTranscriptions(id string) ([]*Transcription, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s/%s", api.concatUserPath(callsPath), id, "transcriptions"), &[]*Transcription{})
-	if err != nil {
-		return nil, err
-	}
-	return *(result.(*[]*Transcription)), nil
+	return listTyped[Transcription](api, fmt.Sprintf("%s/%s/%s", api.concatUserPath(callsPath), id, "transcriptions"), nil)
 }
 
 // CreateGatherData struct
@@ -209,17 +343,22 @@ type GatherPromptData struct {
 	Locale      string `json:"locale,omitempty"`
 	Voice       string `json:"voice,omitempty"`
 	LoopEnabled bool   `json:"loopEnabled,omitempty"`
-	Bargeable   bool   `json:"bargeable, string"`
+	Bargeable   bool   `json:"bargeable"`
 }
 
 // CreateGather gathers the DTMF digits pressed in a call
 // It returns ID of created gather or error
 func (api *Client) CreateGather(id string, data *CreateGatherData) (string, error) {
-	_, headers, err := api.makeRequest(http.MethodPost, fmt.Sprintf("%s/%s/%s", api.concatUserPath(callsPath), id, "gather"), nil, data)
+	if data != nil && data.Prompt != nil {
+		if err := validateVoice(data.Prompt.Voice, data.Prompt.Locale, data.Prompt.Gender); err != nil {
+			return "", err
+		}
+	}
+	result, headers, err := api.makeRequest(http.MethodPost, fmt.Sprintf("%s/%s/%s", api.concatUserPath(callsPath), id, "gather"), nil, data)
 	if err != nil {
 		return "", err
 	}
-	return getIDFromLocationHeader(headers), nil
+	return getCreatedID(result, headers)
 }
 
 // Gather struct
@@ -235,11 +374,7 @@ type Gather struct {
 // GetGather returns the gather DTMF parameters and results of the call
 // It returns Gather instance or error
 func (api *Client) GetGather(id string, gatherID string) (*Gather, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s/%s/%s", api.concatUserPath(callsPath), id, "gather", gatherID), &Gather{})
-	if err != nil {
-		return nil, err
-	}
-	return result.(*Gather), nil
+	return getTyped[Gather](api, fmt.Sprintf("%s/%s/%s/%s", api.concatUserPath(callsPath), id, "gather", gatherID), nil)
 }
 
 // UpdateGatherData struct
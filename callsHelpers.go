@@ -1,5 +1,11 @@
 package bandwidth
 
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
 func mergeMaps(src, dst map[string]interface{}) {
 	if dst == nil {
 		dst = map[string]interface{}{}
@@ -9,11 +15,55 @@ func mergeMaps(src, dst map[string]interface{}) {
 	}
 }
 
+// RecordedCallOptions is optional parameters of CreateRecordedCall()
+type RecordedCallOptions struct {
+	// FileFormat is "wav" (default) or "mp3"
+	FileFormat string
+	// MaxDuration is max recording duration in seconds
+	MaxDuration          int
+	TranscriptionEnabled bool
+	CallbackURL          string
+	Tag                  string
+}
+
+// CreateRecordedCall creates an outbound phone call with enabled recording
+// It returns ID of created call or error
+// example: api.CreateRecordedCall("+19195551212", "+191955512142", &bandwidth.RecordedCallOptions{FileFormat: "mp3", MaxDuration: 3600})
+func (api *Client) CreateRecordedCall(from, to string, opts *RecordedCallOptions) (string, error) {
+	if opts == nil {
+		opts = &RecordedCallOptions{}
+	}
+	switch opts.FileFormat {
+	case "", "wav", "mp3":
+	default:
+		return "", errors.New("Recording file format should be \"wav\" or \"mp3\"")
+	}
+	if opts.MaxDuration < 0 {
+		return "", errors.New("Recording max duration can't be negative")
+	}
+	return api.CreateCall(&CreateCallData{
+		From:                 from,
+		To:                   to,
+		RecordingEnabled:     true,
+		RecordingFileFormat:  opts.FileFormat,
+		RecordingMaxDuration: opts.MaxDuration,
+		TranscriptionEnabled: opts.TranscriptionEnabled,
+		CallbackURL:          opts.CallbackURL,
+		Tag:                  opts.Tag,
+	})
+}
+
 // AnswerIncomingCall  answers an incoming call
+// Optional sipHeaders are custom SIP headers to include to the answer
 // It returns error object
 // example: api.CalAnswerIncomingCall("callId")
-func (api *Client) AnswerIncomingCall(id string) error {
-	_, err := api.UpdateCall(id, &UpdateCallData{State: "active"})
+// api.AnswerIncomingCall("callId", map[string]string{"X-Customer-Id": "123"})
+func (api *Client) AnswerIncomingCall(id string, sipHeaders ...map[string]string) error {
+	data := &UpdateCallData{State: "active"}
+	if len(sipHeaders) > 0 {
+		data.SipHeaders = sipHeaders[0]
+	}
+	_, err := api.UpdateCall(id, data)
 	return err
 }
 
@@ -33,6 +83,18 @@ func (api *Client) HangUpCall(id string) error {
 	return err
 }
 
+// TransferCall transfers an active call to another number
+// whisperAudio (optional) is played to the transfer target before the calls are connected
+// It returns ID of the new call leg or error
+// example: api.TransferCall("callId", "+19195551212", &bandwidth.PlayAudioData{Sentence: "Customer from the web site"})
+func (api *Client) TransferCall(id string, transferTo string, whisperAudio ...*PlayAudioData) (string, error) {
+	data := &UpdateCallData{State: "transferring", TransferTo: transferTo}
+	if len(whisperAudio) > 0 {
+		data.WhisperAudio = whisperAudio[0]
+	}
+	return api.UpdateCall(id, data)
+}
+
 // SetCallRecodingEnabled  hangs up the call
 // It returns error object
 // example: api.SetCallRecodingEnabled("callId", true) // enable recording
@@ -41,6 +103,35 @@ func (api *Client) SetCallRecodingEnabled(id string, enabled bool) error {
 	return err
 }
 
+// PauseCallRecording pauses recording of an active call (e.g. while sensitive data are entered)
+// It returns error object
+// example: api.PauseCallRecording("callId")
+func (api *Client) PauseCallRecording(id string) error {
+	return api.setCallRecordingState(id, RecordingStatePaused)
+}
+
+// ResumeCallRecording resumes paused recording of an active call
+// It returns error object
+// example: api.ResumeCallRecording("callId")
+func (api *Client) ResumeCallRecording(id string) error {
+	return api.setCallRecordingState(id, RecordingStateRecording)
+}
+
+func (api *Client) setCallRecordingState(id string, state string) error {
+	call, err := api.GetCall(id)
+	if err != nil {
+		return err
+	}
+	if call.State != "active" {
+		return fmt.Errorf("Call %s is not active", id)
+	}
+	if !call.RecordingEnabled {
+		return fmt.Errorf("Recording is not enabled for call %s", id)
+	}
+	_, err = api.UpdateCall(id, &UpdateCallData{RecordingState: state})
+	return err
+}
+
 // StopGather stops call's gather
 // It returns error object
 // example: api.StopGather("callId")
@@ -54,3 +145,106 @@ func (api *Client) StopGather(id string, gatherID string) error {
 func (api *Client) SendDTMFCharactersToCall(id string, dtmfOut string) error {
 	return api.SendDTMFToCall(id, &SendDTMFToCallData{DTMFOut: dtmfOut})
 }
+
+// UpdateCallAndFetch makes changes of an active call like UpdateCall() and returns resulting state of the call
+// Use UpdateCall() if you don't need the call data to avoid extra request.
+// It returns Call instance or error
+// example: call, err := api.UpdateCallAndFetch("callId", &bandwidth.UpdateCallData{State: "active"})
+func (api *Client) UpdateCallAndFetch(id string, changedData *UpdateCallData) (*Call, error) {
+	call := &Call{}
+	if err := api.updateResourceAndFetch(fmt.Sprintf("%s/%s", api.concatUserPath(callsPath), id), changedData, call); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+// GetCallsByIDs returns information about several calls (requests are made in parallel)
+// It returns map of found Call instances by id and *BatchError with errors for calls which can't be received
+// example: calls, err := api.GetCallsByIDs([]string{"callId1", "callId2"})
+func (api *Client) GetCallsByIDs(ids []string) (map[string]*Call, error) {
+	calls := make([]*Call, len(ids))
+	errs := api.runBatch(len(ids), func(i int) error {
+		call, err := api.GetCall(ids[i])
+		calls[i] = call
+		return err
+	})
+	result := make(map[string]*Call, len(ids))
+	batchErr := &BatchError{Errors: map[string]error{}}
+	for i, id := range ids {
+		if errs[i] != nil {
+			batchErr.Errors[id] = errs[i]
+			continue
+		}
+		result[id] = calls[i]
+	}
+	if len(batchErr.Errors) > 0 {
+		return result, batchErr
+	}
+	return result, nil
+}
+
+// maxTransferChainLength limits count of legs returned by GetCallTransferChain()
+const maxTransferChainLength = 20
+
+// GetCallTransferChain returns the call and all legs it was transferred to (in transfer order)
+// The API doesn't link transfer legs, so they are found by heuristics:
+// a leg with TransferTo set was transferred, the next leg is the earliest outbound call to TransferTo
+// started not before the "transfer" event of the leg (or the leg's start if there is no such event).
+// Each call is included once (so cycles of transfers are not followed), the chain is limited to 20 legs.
+// All calls to each TransferTo number are listed (page by page), so numbers with many calls make it slow.
+// It returns list of Call instances (starting from the given call) or error
+// example: legs, err := api.GetCallTransferChain("callId")
+func (api *Client) GetCallTransferChain(callID string) ([]*Call, error) {
+	call, err := api.GetCall(callID)
+	if err != nil {
+		return nil, err
+	}
+	chain := []*Call{call}
+	seen := map[string]bool{call.ID: true}
+	for call.TransferTo != "" && len(chain) < maxTransferChainLength {
+		next, err := api.findTransferLeg(call, seen)
+		if err != nil {
+			return nil, err
+		}
+		if next == nil {
+			break
+		}
+		chain = append(chain, next)
+		seen[next.ID] = true
+		call = next
+	}
+	return chain, nil
+}
+
+func (api *Client) findTransferLeg(call *Call, seen map[string]bool) (*Call, error) {
+	events, err := api.GetCallEvents(call.ID)
+	if err != nil {
+		return nil, err
+	}
+	transferTime := parseTime(call.StartTime)
+	for _, event := range events {
+		if event.Name == "transfer" {
+			transferTime = parseTime(event.Time)
+			break
+		}
+	}
+	candidates, err := api.GetAllCalls(&GetCallsQuery{To: call.TransferTo, Size: maxPageSize})
+	if err != nil {
+		return nil, err
+	}
+	var leg *Call
+	var legStart time.Time
+	for _, candidate := range candidates {
+		if seen[candidate.ID] || candidate.Direction != "out" {
+			continue
+		}
+		start := parseTime(candidate.StartTime)
+		if start.IsZero() || start.Before(transferTime) {
+			continue
+		}
+		if leg == nil || start.Before(legStart) {
+			leg, legStart = candidate, start
+		}
+	}
+	return leg, nil
+}
@@ -19,6 +19,27 @@ func TestAnswerIncomingCall(t *testing.T) {
 	}
 }
 
+func TestAnswerIncomingCallWithSipHeaders(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls/123",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"state":"active","sipHeaders":{"X-Customer-Id":"456"}}`}})
+	defer server.Close()
+	err := api.AnswerIncomingCall("123", map[string]string{"X-Customer-Id": "456"})
+	if err != nil {
+		t.Error("Failed call of AnswerIncomingCall()")
+		return
+	}
+}
+
+func TestAnswerIncomingCallWithInvalidSipHeaders(t *testing.T) {
+	api := getAPI()
+	err := api.AnswerIncomingCall("123", map[string]string{" ": "456"})
+	if err == nil {
+		t.Error("Should fail here")
+	}
+}
+
 func TestRejectIncomingCall(t *testing.T) {
 	server, api := startMockServer(t, []RequestHandler{RequestHandler{
 		PathAndQuery:     "/v1/users/userId/calls/123",
@@ -58,6 +79,55 @@ func TestSetCallRecodingEnabled(t *testing.T) {
 	}
 }
 
+func TestPauseCallRecording(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/123",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "123", "state": "active", "recordingEnabled": true, "recordingState": "recording"}`}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls/123",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"recordingState":"paused"}`}})
+	defer server.Close()
+	err := api.PauseCallRecording("123")
+	if err != nil {
+		t.Error("Failed call of PauseCallRecording()")
+		return
+	}
+}
+
+func TestResumeCallRecording(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/123",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "123", "state": "active", "recordingEnabled": true, "recordingState": "paused"}`}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls/123",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"recordingState":"recording"}`}})
+	defer server.Close()
+	err := api.ResumeCallRecording("123")
+	if err != nil {
+		t.Error("Failed call of ResumeCallRecording()")
+		return
+	}
+}
+
+func TestPauseCallRecordingFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/123",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "123", "state": "active", "recordingEnabled": false}`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/456",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "456", "state": "completed", "recordingEnabled": true}`}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls/789",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusNotFound}})
+	defer server.Close()
+	shouldFail(t, func() (interface{}, error) { return nil, api.PauseCallRecording("123") })
+	shouldFail(t, func() (interface{}, error) { return nil, api.PauseCallRecording("456") })
+	shouldFail(t, func() (interface{}, error) { return nil, api.PauseCallRecording("789") })
+}
+
 func TestStopGather(t *testing.T) {
 	server, api := startMockServer(t, []RequestHandler{RequestHandler{
 		PathAndQuery:     "/v1/users/userId/calls/123/gather/456",
@@ -83,3 +153,179 @@ func TestSendDTMFCharactersToCalll(t *testing.T) {
 		return
 	}
 }
+
+func TestUpdateCallAndFetch(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls/123",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"state":"active"}`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/123",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "123", "state": "active"}`}})
+	defer server.Close()
+	call, err := api.UpdateCallAndFetch("123", &UpdateCallData{State: "active"})
+	if err != nil {
+		t.Error("Failed call of UpdateCallAndFetch()")
+		return
+	}
+	expect(t, call.State, "active")
+}
+
+func TestUpdateCallAndFetchFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls/123",
+		Method:           http.MethodPost,
+		StatusCodeToSend: http.StatusBadRequest}})
+	defer server.Close()
+	shouldFail(t, func() (interface{}, error) { return api.UpdateCallAndFetch("123", &UpdateCallData{State: "active"}) })
+}
+
+func TestTransferCall(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls/123",
+		Method:           http.MethodPost,
+		HeadersToSend:    map[string]string{"Location": "/v1/users/{userId}/calls/456"},
+		EstimatedContent: `{"transferTo":"+1234567890","state":"transferring","whisperAudio":{"fileUrl":"file.mp3"}}`}})
+	defer server.Close()
+	id, err := api.TransferCall("123", "+1234567890", &PlayAudioData{FileURL: "file.mp3"})
+	if err != nil {
+		t.Error("Failed call of TransferCall()")
+		return
+	}
+	expect(t, id, "456")
+}
+
+func TestGetCallsByIDs(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/1",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "1"}`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/2",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "2"}`}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls/3",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusNotFound}})
+	defer server.Close()
+	calls, err := api.GetCallsByIDs([]string{"1", "2", "3"})
+	expect(t, len(calls), 2)
+	expect(t, calls["1"].ID, "1")
+	expect(t, calls["2"].ID, "2")
+	batchErr, ok := err.(*BatchError)
+	if !ok {
+		t.Fatal("Should return BatchError")
+	}
+	expect(t, len(batchErr.Errors), 1)
+	if batchErr.Errors["3"] == nil {
+		t.Error("Should contain error for call 3")
+	}
+	calls, err = api.GetCallsByIDs([]string{"1"})
+	expectNil(t, err)
+	expect(t, len(calls), 1)
+}
+
+func TestCreateRecordedCall(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"from":"fromNumber","recordingFileFormat":"mp3","recordingEnabled":true,"recordingMaxDuration":3600,"to":"toNumber"}`,
+		HeadersToSend:    map[string]string{"Location": "/v1/users/{userId}/calls/123"}}})
+	defer server.Close()
+	id, err := api.CreateRecordedCall("fromNumber", "toNumber", &RecordedCallOptions{FileFormat: "mp3", MaxDuration: 3600})
+	if err != nil {
+		t.Error("Failed call of CreateRecordedCall()")
+		return
+	}
+	expect(t, id, "123")
+}
+
+func TestCreateRecordedCallWithDefaults(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"from":"fromNumber","recordingEnabled":true,"to":"toNumber"}`,
+		HeadersToSend:    map[string]string{"Location": "/v1/users/{userId}/calls/123"}}})
+	defer server.Close()
+	id, err := api.CreateRecordedCall("fromNumber", "toNumber", nil)
+	if err != nil {
+		t.Error("Failed call of CreateRecordedCall()")
+		return
+	}
+	expect(t, id, "123")
+}
+
+func TestCreateRecordedCallFail(t *testing.T) {
+	api := getAPI()
+	shouldFail(t, func() (interface{}, error) {
+		return api.CreateRecordedCall("fromNumber", "toNumber", &RecordedCallOptions{FileFormat: "ogg"})
+	})
+	shouldFail(t, func() (interface{}, error) {
+		return api.CreateRecordedCall("fromNumber", "toNumber", &RecordedCallOptions{MaxDuration: -1})
+	})
+}
+
+func TestGetCallTransferChain(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: "/v1/users/userId/calls/1",
+		Method:       http.MethodGet,
+		ContentToSend: `{"id": "1", "direction": "in", "startTime": "2017-01-10T10:00:00Z", "transferTo": "+19195551212"}`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/1/events",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"name": "create", "time": "2017-01-10T10:00:00Z"}, {"name": "transfer", "time": "2017-01-10T10:01:00Z"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls?size=1000&to=%2B19195551212",
+		Method:        http.MethodGet,
+		HeadersToSend: map[string]string{"Link": `<https://api.catapult.inetwork.com/v1/users/userId/calls?page=1&size=1000&to=%2B19195551212>; rel="next"`},
+		ContentToSend: `[
+			{"id": "old", "direction": "out", "startTime": "2017-01-10T09:00:00Z"},
+			{"id": "later", "direction": "out", "startTime": "2017-01-10T11:00:00Z"},
+			{"id": "inbound", "direction": "in", "startTime": "2017-01-10T10:01:00Z"}
+		]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls?page=1&size=1000&to=%2B19195551212",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "2", "direction": "out", "startTime": "2017-01-10T10:01:01Z", "transferTo": "+19195551213"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/2/events",
+		Method:        http.MethodGet,
+		ContentToSend: `[]`}, RequestHandler{
+		PathAndQuery: "/v1/users/userId/calls?size=1000&to=%2B19195551213",
+		Method:       http.MethodGet,
+		ContentToSend: `[
+			{"id": "1", "direction": "out", "startTime": "2017-01-10T10:05:00Z"},
+			{"id": "3", "direction": "out", "startTime": "2017-01-10T10:06:00Z"}
+		]`}})
+	defer server.Close()
+	chain, err := api.GetCallTransferChain("1")
+	if err != nil {
+		t.Fatal("Failed call of GetCallTransferChain()")
+	}
+	expect(t, len(chain), 3)
+	expect(t, chain[0].ID, "1")
+	expect(t, chain[1].ID, "2")
+	expect(t, chain[2].ID, "3")
+}
+
+func TestGetCallTransferChainWithoutTransfers(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/1",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "1"}`}})
+	defer server.Close()
+	chain, err := api.GetCallTransferChain("1")
+	expectNil(t, err)
+	expect(t, len(chain), 1)
+}
+
+func TestGetCallTransferChainFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls/1",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusNotFound}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/2",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "2", "transferTo": "+19195551212"}`}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls/2/events",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusBadRequest}})
+	defer server.Close()
+	shouldFail(t, func() (interface{}, error) { return api.GetCallTransferChain("1") })
+	shouldFail(t, func() (interface{}, error) { return api.GetCallTransferChain("2") })
+}
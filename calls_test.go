@@ -43,6 +43,20 @@ func TestGetCalls(t *testing.T) {
 	expect(t, len(result), 2)
 }
 
+func TestGetCallsWithQueryParams(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls?from=%2B1234567890&page=0&size=5",
+		Method:        http.MethodGet,
+		ContentToSend: `[]`}})
+	defer server.Close()
+	result, err := api.GetCalls(&GetCallsQuery{From: "+1234567890", Size: 10, QueryParams: QueryParams{"page": "0", "size": "5"}})
+	if err != nil {
+		t.Error("Failed call of GetCalls()")
+		return
+	}
+	expect(t, len(result), 0)
+}
+
 func TestGetCallsFail(t *testing.T) {
 	server, api := startMockServer(t, []RequestHandler{RequestHandler{
 		PathAndQuery:     "/v1/users/userId/calls",
@@ -69,6 +83,51 @@ func TestCreateCall(t *testing.T) {
 	expect(t, id, "123")
 }
 
+func TestCreateCallWithNormalizedNumber(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"from":"+19195551213","to":"+19195551212"}`,
+		HeadersToSend:    map[string]string{"Location": "/v1/users/{userId}/calls/123"}}})
+	defer server.Close()
+	data := &CreateCallData{
+		From: "+19195551213",
+		To:   "(919) 555-1212"}
+	id, err := api.CreateCall(data)
+	if err != nil {
+		t.Error("Failed call of CreateCall()")
+		return
+	}
+	expect(t, id, "123")
+	expect(t, data.To, "(919) 555-1212")
+}
+
+func TestCreateCallToSIPURI(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"from":"+19195551213","to":"sip:john@example.com"}`,
+		HeadersToSend:    map[string]string{"Location": "/v1/users/{userId}/calls/123"}}})
+	defer server.Close()
+	id, err := api.CreateCall(&CreateCallData{
+		From: "+19195551213",
+		To:   "sip:john@example.com"})
+	if err != nil {
+		t.Error("Failed call of CreateCall()")
+		return
+	}
+	expect(t, id, "123")
+}
+
+func TestCreateCallToInvalidSIPURI(t *testing.T) {
+	api := getAPI()
+	shouldFail(t, func() (interface{}, error) {
+		return api.CreateCall(&CreateCallData{
+			From: "+19195551213",
+			To:   "sip:john"})
+	})
+}
+
 func TestCreateCallFail(t *testing.T) {
 	server, api := startMockServer(t, []RequestHandler{RequestHandler{
 		PathAndQuery:     "/v1/users/userId/calls",
@@ -82,13 +141,74 @@ func TestCreateCallFail(t *testing.T) {
 	})
 }
 
+func TestCreateCallWithIDInBody(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"from":"fromNumber","to":"toNumber"}`,
+		ContentToSend:    `{"id": "123"}`}})
+	defer server.Close()
+	id, err := api.CreateCall(&CreateCallData{
+		From: "fromNumber",
+		To:   "toNumber"})
+	if err != nil {
+		t.Error("Failed call of CreateCall()")
+		return
+	}
+	expect(t, id, "123")
+}
+
+func TestCreateCallWithoutLocation(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls",
+		Method:           http.MethodPost,
+		StatusCodeToSend: http.StatusCreated}})
+	defer server.Close()
+	shouldFail(t, func() (interface{}, error) {
+		return api.CreateCall(&CreateCallData{
+			From: "fromNumber",
+			To:   "toNumber"})
+	})
+}
+
+func TestCreateCallWithCallerIDPresentation(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"from":"fromNumber","to":"toNumber","callerIdPresentation":"restricted"}`,
+		HeadersToSend:    map[string]string{"Location": "/v1/users/{userId}/calls/123"}}})
+	defer server.Close()
+	id, err := api.CreateCall(&CreateCallData{
+		From:                 "fromNumber",
+		To:                   "toNumber",
+		CallerIDPresentation: CallerIDPresentationRestricted})
+	if err != nil {
+		t.Error("Failed call of CreateCall()")
+		return
+	}
+	expect(t, id, "123")
+}
+
+func TestCreateCallWithInvalidCallerIDPresentation(t *testing.T) {
+	api := getAPI()
+	shouldFail(t, func() (interface{}, error) {
+		return api.CreateCall(&CreateCallData{
+			From:                 "fromNumber",
+			To:                   "toNumber",
+			CallerIDPresentation: "hidden"})
+	})
+}
+
 func TestGetCall(t *testing.T) {
 	server, api := startMockServer(t, []RequestHandler{RequestHandler{
 		PathAndQuery: "/v1/users/userId/calls/123",
 		Method:       http.MethodGet,
 		ContentToSend: `{
 			"id": "{callId}",
-			"state": "completed"
+			"state": "completed",
+			"startTime": "2013-02-08T13:15:47.587Z",
+			"activeTime": "2013-02-08T13:15:52.347Z",
+			"endTime": "2013-02-08T13:15:55.887Z"
 		}`}})
 	defer server.Close()
 	result, err := api.GetCall("123")
@@ -97,6 +217,27 @@ func TestGetCall(t *testing.T) {
 		return
 	}
 	expect(t, result.ID, "{callId}")
+	expect(t, result.StartTime, "2013-02-08T13:15:47.587Z")
+	expect(t, result.ActiveTime, "2013-02-08T13:15:52.347Z")
+	expect(t, result.EndTime, "2013-02-08T13:15:55.887Z")
+}
+
+func TestGetCallWithDiversion(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: "/v1/users/userId/calls/123",
+		Method:       http.MethodGet,
+		ContentToSend: `{
+			"id": "{callId}",
+			"state": "active",
+			"diversion": {"origTo": "+13233326957", "reason": "user-busy"}
+		}`}})
+	defer server.Close()
+	result, err := api.GetCall("123")
+	if err != nil {
+		t.Error("Failed call of GetCall()")
+		return
+	}
+	expect(t, result.Diversion, &CallDiversion{OrigTo: "+13233326957", Reason: "user-busy"})
 }
 
 func TestGetCallFail(t *testing.T) {
@@ -136,6 +277,34 @@ func TestUpdateCallWithLocationHeader(t *testing.T) {
 	expect(t, id, "456")
 }
 
+func TestUpdateCallWithWhisperAudio(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls/123",
+		Method:           http.MethodPost,
+		HeadersToSend:    map[string]string{"Location": "/v1/users/{userId}/calls/456"},
+		EstimatedContent: `{"transferTo":"+1234567890","state":"transferring","whisperAudio":{"sentence":"Hello","gender":"female"}}`}})
+	defer server.Close()
+	id, err := api.UpdateCall("123", &UpdateCallData{
+		State:        "transferring",
+		TransferTo:   "+1234567890",
+		WhisperAudio: &PlayAudioData{Sentence: "Hello", Gender: "female"}})
+	if err != nil {
+		t.Error("Failed call of UpdateCall()")
+		return
+	}
+	expect(t, id, "456")
+}
+
+func TestUpdateCallWithInvalidWhisperAudio(t *testing.T) {
+	api := getAPI()
+	shouldFail(t, func() (interface{}, error) {
+		return api.UpdateCall("123", &UpdateCallData{
+			State:        "transferring",
+			TransferTo:   "+1234567890",
+			WhisperAudio: &PlayAudioData{Sentence: "Hello", FileURL: "file.mp3"}})
+	})
+}
+
 func TestPlayAudioToCall(t *testing.T) {
 	server, api := startMockServer(t, []RequestHandler{RequestHandler{
 		PathAndQuery:     "/v1/users/userId/calls/123/audio",
@@ -189,6 +358,11 @@ func TestGetCallEvents(t *testing.T) {
 			"id": "{callEventId2}",
 			"time": "2012-09-19T13:55:45.583Z",
 			"name": "answer"
+		},
+		{
+			"id": "{callEventId3}",
+			"time": "2012-09-19T14:55:45.583Z",
+			"name": "maxDuration"
 		}]`}})
 	defer server.Close()
 	result, err := api.GetCallEvents("123")
@@ -196,7 +370,10 @@ func TestGetCallEvents(t *testing.T) {
 		t.Error("Failed call of GetCallEvents()")
 		return
 	}
-	expect(t, len(result), 2)
+	expect(t, len(result), 3)
+	expect(t, result[1].IsTimeout(), false)
+	expect(t, result[2].IsTimeout(), true)
+	expect(t, (&CallEvent{Name: CallEventTimeout}).IsTimeout(), true)
 }
 
 func TestGetCallEventsFail(t *testing.T) {
@@ -224,6 +401,7 @@ func TestGetCallEvent(t *testing.T) {
 		return
 	}
 	expect(t, result.ID, "{callEventId1}")
+	expect(t, result.Time, "2012-09-19T13:55:41.343Z")
 }
 
 func TestGetCallEventFail(t *testing.T) {
@@ -308,6 +486,17 @@ func TestCreateGather(t *testing.T) {
 	expect(t, id, "456")
 }
 
+func TestCreateGatherWithPrompt(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls/123/gather",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"maxDigits":"1","prompt":{"sentence":"Press 1","bargeable":true}}`,
+		HeadersToSend:    map[string]string{"Location": "/v1/users/{userId}/calls/123/gather/456"}}})
+	defer server.Close()
+	_, err := api.CreateGather("123", &CreateGatherData{MaxDigits: 1, Prompt: &GatherPromptData{Sentence: "Press 1", Bargeable: true}})
+	expectNil(t, err)
+}
+
 func TestCreateGatherFail(t *testing.T) {
 	server, api := startMockServer(t, []RequestHandler{RequestHandler{
 		PathAndQuery:     "/v1/users/userId/calls/123/gather",
@@ -338,6 +527,8 @@ func TestGetGather(t *testing.T) {
 		return
 	}
 	expect(t, result.ID, "{gatherId}")
+	expect(t, result.CreatedTime, "2014-02-12T19:33:56Z")
+	expect(t, result.CompletedTime, "2014-02-12T19:33:59Z")
 }
 
 func TestGetGatherFail(t *testing.T) {
@@ -2,19 +2,28 @@ package bandwidth
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
+// DefaultMaxErrorBodySize is max size of error response body read by the client when Client.MaxErrorBodySize is not set
+const DefaultMaxErrorBodySize = 4 << 20
+
+// maximal page size allowed by list endpoints
+var maxPageSize = 1000
+
 // RateLimitError is error for 429 http error
 type RateLimitError struct {
 	Reset time.Time
@@ -24,29 +33,436 @@ func (e *RateLimitError) Error() string {
 	return fmt.Sprintf("RateLimitError: reset at %v", e.Reset)
 }
 
+// TransportError is error of sending the request or receiving the response (like connection failure or timeout)
+// Use errors.As() to distinguish it from API errors (*APIError and *RateLimitError).
+// example: var transportErr *bandwidth.TransportError
+// if errors.As(err, &transportErr) && transportErr.Timeout() { ... } // request timed out
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns original error of http client
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+// Timeout returns true if the request timed out
+func (e *TransportError) Timeout() bool {
+	var netErr net.Error
+	return errors.As(e.Err, &netErr) && netErr.Timeout()
+}
+
+// APIError is error response of the API (except rate limit errors which are returned as RateLimitError)
+type APIError struct {
+	StatusCode int
+	// Code and Message are "code" and "message" fields of the error response (if any)
+	Code    string
+	Message string
+	// RawBody is body of the error response (truncated to Client.MaxErrorBodySize if Truncated is set)
+	RawBody   string
+	Truncated bool
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	if e.Code != "" {
+		return e.Code
+	}
+	if e.Truncated {
+		return fmt.Sprintf("Http code %d: %s... (truncated to %d bytes)", e.StatusCode, e.RawBody, len(e.RawBody))
+	}
+	// JSON objects without message are not useful in error text
+	if body := strings.TrimSpace(e.RawBody); body != "" && !strings.HasPrefix(body, "{") {
+		return fmt.Sprintf("Http code %d: %s", e.StatusCode, body)
+	}
+	return fmt.Sprintf("Http code %d", e.StatusCode)
+}
+
+// errorField returns field of error response as string (values of other types are formatted)
+func errorField(errorBody map[string]interface{}, name string) string {
+	switch value := errorBody[name].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// RetryAfter returns time to wait before next request
+func (e *RateLimitError) RetryAfter() time.Duration {
+	wait := e.Reset.Sub(timeNow())
+	if wait < 0 {
+		return 0
+	}
+	return wait
+}
+
 // Client is main API object
 type Client struct {
+	// APIToken and APISecret should be changed by UpdateCredentials() when the client is in use
 	UserID, APIToken, APISecret string
 	APIEndPoint                 string
-	HTTPClient                  *http.Client
+
+	// BasePathPrefix (if set) is inserted between APIEndPoint and API version of request urls (see WithBasePathPrefix())
+	BasePathPrefix string
+
+	// HTTPClient makes the requests (own client of the instance, see WithHTTPClient() and WithTimeout())
+	HTTPClient *http.Client
+
+	// UserAgent is value of User-Agent header of requests (DefaultUserAgent if empty, see WithUserAgent())
+	UserAgent string
+
+	// CallbackCheckTimeout limits CheckCallbackURL() probes (DefaultCallbackCheckTimeout if zero)
+	CallbackCheckTimeout time.Duration
+
+	// SMSSegmentRate and MMSRate are prices used by EstimateMessageCost() (see WithMessageRates())
+	SMSSegmentRate, MMSRate float64
+
+	// MethodOverrideHeader (if set) is header with real method of non GET/POST requests which are sent as POST (see WithMethodOverride())
+	MethodOverrideHeader string
+
+	// MaxErrorBodySize limits size of error response body read by the client (DefaultMaxErrorBodySize if zero)
+	MaxErrorBodySize int64
+
+	// BatchConcurrency limits count of parallel requests made by batch methods like GetCallsByIDs() (DefaultBatchConcurrency if zero)
+	BatchConcurrency int
+
+	// IdempotencyStore keeps results of CreateMessageOnce() (in-memory store of the client is used if nil)
+	IdempotencyStore IdempotencyStore
+
+	// MaxRateLimitRetries is count of retries of requests failed with RateLimitError (zero means no retries, see WithRateLimitRetries())
+	MaxRateLimitRetries int
+
+	// RateLimitCushion (if set) makes the client slow down when less requests remain in current rate limit window (see WithRateLimitCushion())
+	RateLimitCushion int
+
+	// TestMode (if set) blocks calls and messages to numbers which are not in AllowedNumbers (see WithTestMode())
+	TestMode       bool
+	AllowedNumbers []string
+
+	// APIVersions overrides API versions of resources (see APIVersion()), e.g. to try early access endpoints
+	APIVersions map[string]string
+
+	// RequestLogger (if set) is called after each API request with its data (url and bodies don't contain credentials)
+	// Status code is zero and response body is nil if the request failed before getting a response.
+	// Media file contents are not logged (bodies of successful media uploads and downloads are nil, duration is time to response headers).
+	// Bodies must not be modified.
+	RequestLogger func(method, url string, requestBody []byte, statusCode int, responseBody []byte, duration time.Duration)
+
+	// CollectTiming (if set) makes the client collect time breakdown of requests (see WithTiming() and LastTiming())
+	CollectTiming bool
+
+	pool *connectionPool
+
+	// shared is state of the client and its copies made by WithContext() (see state())
+	shared *clientState
+
+	// ctx (if set) is context of the requests (see WithContext())
+	ctx context.Context
+
+	timeout time.Duration
+}
+
+// clientState is mutable state of Client
+// It is created on first use, so Client values which are not made by New() work too.
+type clientState struct {
+	idempotencyMutex sync.Mutex
+	idempotencyKeys  map[string]*idempotencyKeyLock
+	idempotencyStore IdempotencyStore
+
+	rateLimitMutex sync.Mutex
+	rateLimit      rateLimitState
+	lastRateLimit  *RateLimit
+
+	timingMutex sync.Mutex
+	lastTiming  *Timing
+
+	credentialsMutex sync.RWMutex
+}
+
+// clientStateMutex guards lazy creation of state of clients
+var clientStateMutex sync.Mutex
+
+// state returns shared state of the client (creating it if needed)
+func (c *Client) state() *clientState {
+	clientStateMutex.Lock()
+	defer clientStateMutex.Unlock()
+	if c.shared == nil {
+		c.shared = &clientState{}
+	}
+	return c.shared
+}
+
+// API versions
+const (
+	APIVersion1 = "v1"
+	APIVersion2 = "v2"
+)
+
+// messagesV2Resource is resource name of messaging methods with V2 suffix (like CreateMessageV2())
+const messagesV2Resource = "messagesV2"
+
+// defaultAPIVersions lists resources which don't use v1 API
+var defaultAPIVersions = map[string]string{
+	messagesV2Resource: APIVersion2,
+}
+
+// EndpointUS is Catapult API endpoint for US region (default)
+const EndpointUS = "https://api.catapult.inetwork.com"
+
+var knownEndpoints = []string{EndpointUS}
+
+// Option is optional setting of Client which can be passed to New()
+type Option func(*Client) error
+
+// WithEndpoint sets API endpoint. Use one of Endpoint* constants or any absolute http(s) url (e.g. for sandbox)
+// example: api := bandwidth.New("userId", "apiToken", "apiSecret", bandwidth.WithEndpoint(bandwidth.EndpointUS))
+func WithEndpoint(endpoint string) Option {
+	return func(c *Client) error {
+		if err := validateEndpoint(endpoint); err != nil {
+			return err
+		}
+		c.APIEndPoint = strings.TrimRight(endpoint, "/")
+		return nil
+	}
+}
+
+// WithBasePathPrefix sets path prefix of API urls (e.g. for a reverse proxy which routes requests to Catapult by prefix)
+// example: api := bandwidth.New("userId", "apiToken", "apiSecret", bandwidth.WithEndpoint("https://gateway.local"), bandwidth.WithBasePathPrefix("/bandwidth-proxy"))
+// // requests go to https://gateway.local/bandwidth-proxy/v1/users/userId/...
+func WithBasePathPrefix(prefix string) Option {
+	return func(c *Client) error {
+		if strings.ContainsAny(prefix, "?#") || strings.Contains(prefix, "://") {
+			return fmt.Errorf("Invalid base path prefix %q", prefix)
+		}
+		c.BasePathPrefix = prefix
+		return nil
+	}
+}
+
+// WithHTTPClient makes the client send requests by given http client (e.g. with own transport or timeout)
+// Connection pool options and WithTimeout() are ignored then.
+// example: api := bandwidth.New("userId", "apiToken", "apiSecret", bandwidth.WithHTTPClient(&http.Client{Timeout: 30 * time.Second}))
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) error {
+		if client == nil {
+			return errors.New("Missing http client")
+		}
+		c.HTTPClient = client
+		return nil
+	}
+}
+
+// WithTimeout limits time of each request including reading of the response body (zero means no limit)
+// It is ignored if WithHTTPClient() is used (set Timeout of your http client instead).
+// example: api := bandwidth.New("userId", "apiToken", "apiSecret", bandwidth.WithTimeout(30 * time.Second))
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return errors.New("Timeout can't be negative")
+		}
+		c.timeout = timeout
+		return nil
+	}
+}
+
+// DefaultUserAgent is User-Agent header of requests when Client.UserAgent is not set
+const DefaultUserAgent = "go-bandwidth/v" + Version
+
+// WithUserAgent sets User-Agent header of the requests (e.g. to identify your application in API logs)
+// example: api := bandwidth.New("userId", "apiToken", "apiSecret", bandwidth.WithUserAgent("my-app/1.0 "+bandwidth.DefaultUserAgent))
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) error {
+		c.UserAgent = userAgent
+		return nil
+	}
+}
+
+// DefaultMethodOverrideHeader is used by WithMethodOverride() if header name is empty
+const DefaultMethodOverrideHeader = "X-HTTP-Method-Override"
+
+// WithMethodOverride makes the client send PUT, PATCH and DELETE requests as POST with real method in given header
+// (for proxies which block these methods)
+// example: api := bandwidth.New("userId", "apiToken", "apiSecret", bandwidth.WithMethodOverride("X-HTTP-Method-Override"))
+func WithMethodOverride(header string) Option {
+	return func(c *Client) error {
+		if header == "" {
+			header = DefaultMethodOverrideHeader
+		}
+		c.MethodOverrideHeader = header
+		return nil
+	}
+}
+
+type connectionPool struct {
+	maxIdleConns    int
+	maxConnsPerHost int
+	idleConnTimeout time.Duration
+}
+
+func (c *Client) connectionPool() *connectionPool {
+	if c.pool == nil {
+		c.pool = &connectionPool{}
+	}
+	return c.pool
+}
+
+// WithMaxIdleConns sets max count of idle (keep-alive) connections to API kept by the client
+// Connection pool options are ignored if HTTPClient of the client is replaced by own instance
+// example: api := bandwidth.New("userId", "apiToken", "apiSecret", bandwidth.WithMaxIdleConns(100))
+func WithMaxIdleConns(count int) Option {
+	return func(c *Client) error {
+		if count < 0 {
+			return fmt.Errorf("Invalid max idle connections count %d", count)
+		}
+		c.connectionPool().maxIdleConns = count
+		return nil
+	}
+}
+
+// WithMaxConnsPerHost limits count of connections (active and idle) to API (zero means no limit)
+// Connection pool options are ignored if HTTPClient of the client is replaced by own instance
+func WithMaxConnsPerHost(count int) Option {
+	return func(c *Client) error {
+		if count < 0 {
+			return fmt.Errorf("Invalid max connections count %d", count)
+		}
+		c.connectionPool().maxConnsPerHost = count
+		return nil
+	}
+}
+
+// WithIdleConnTimeout sets how long an idle connection to API is kept open (zero means no limit)
+// Connection pool options are ignored if HTTPClient of the client is replaced by own instance
+func WithIdleConnTimeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout < 0 {
+			return fmt.Errorf("Invalid idle connection timeout %v", timeout)
+		}
+		c.connectionPool().idleConnTimeout = timeout
+		return nil
+	}
+}
+
+// newTransport returns a copy of the default transport tuned by pool settings
+func (pool *connectionPool) newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if pool.maxIdleConns > 0 {
+		// all requests go to the same host so both limits should be raised
+		transport.MaxIdleConns = pool.maxIdleConns
+		transport.MaxIdleConnsPerHost = pool.maxIdleConns
+	}
+	if pool.maxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = pool.maxConnsPerHost
+	}
+	if pool.idleConnTimeout > 0 {
+		transport.IdleConnTimeout = pool.idleConnTimeout
+	}
+	return transport
+}
+
+func validateEndpoint(endpoint string) error {
+	for _, known := range knownEndpoints {
+		if endpoint == known {
+			return nil
+		}
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || !u.IsAbs() || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("Invalid API endpoint %q. Please use one of bandwidth.Endpoint* constants or absolute http(s) url", endpoint)
+	}
+	return nil
 }
 
 // New creates new instances of api
 // It returns Client instance. Use it to make API calls.
+// Optional arguments are Option values (like WithEndpoint()) or API endpoint url string (legacy form)
 // example: api := bandwidth.New("userId", "apiToken", "apiSecret")
-func New(userID, apiToken, apiSecret string, other ...string) (*Client, error) {
-	apiEndPoint := "https://api.catapult.inetwork.com"
+func New(userID, apiToken, apiSecret string, other ...interface{}) (*Client, error) {
 	if userID == "" || apiToken == "" || apiSecret == "" {
 		return nil, errors.New("Missing auth data. Please use api := bandwidth.New(\"user-id\", \"api-token\", \"api-secret\")")
 	}
-	l := len(other)
-	if l > 0 {
-		apiEndPoint = other[0]
+	client := &Client{
+		UserID:      userID,
+		APIToken:    apiToken,
+		APISecret:   apiSecret,
+		APIEndPoint: EndpointUS,
+		shared:      &clientState{},
+	}
+	for _, item := range other {
+		switch option := item.(type) {
+		case string:
+			client.APIEndPoint = option
+		case Option:
+			if err := option(client); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("Unsupported option %v (%T)", item, item)
+		}
+	}
+	if client.HTTPClient == nil {
+		// own client and transport keep settings of the instance away from shared http.DefaultClient
+		client.HTTPClient = &http.Client{Transport: client.connectionPool().newTransport(), Timeout: client.timeout}
 	}
-	client := &Client{userID, apiToken, apiSecret, apiEndPoint, http.DefaultClient}
 	return client, nil
 }
 
+// WithContext returns a copy of the client which makes requests with given context (e.g. to cancel them or set deadline)
+// The copy shares rate limit state with the original client. Rate limit retry waits are aborted when the context is done.
+// Credentials of the copy are not changed by later UpdateCredentials() calls of the original client, so make copies per call.
+// example: call, err := api.WithContext(ctx).GetCall("callId")
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
+	}
+	state := c.state()
+	state.credentialsMutex.RLock()
+	client := *c
+	state.credentialsMutex.RUnlock()
+	client.ctx = ctx
+	return &client
+}
+
+// context returns context of the requests
+func (c *Client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// UpdateCredentials replaces API token and secret used by the client (safe for concurrent use with requests)
+// Connection pool and rate limit state of the client are kept.
+// It returns error object
+// example: err := api.UpdateCredentials("newApiToken", "newApiSecret")
+func (c *Client) UpdateCredentials(apiToken, apiSecret string) error {
+	if apiToken == "" || apiSecret == "" {
+		return errors.New("Missing API token or secret")
+	}
+	state := c.state()
+	state.credentialsMutex.Lock()
+	defer state.credentialsMutex.Unlock()
+	c.APIToken, c.APISecret = apiToken, apiSecret
+	return nil
+}
+
+// credentials returns API token and secret of the client
+func (c *Client) credentials() (string, string) {
+	state := c.state()
+	state.credentialsMutex.RLock()
+	defer state.credentialsMutex.RUnlock()
+	return c.APIToken, c.APISecret
+}
+
 func (c *Client) concatUserPath(path string) string {
 	if path[0] != '/' {
 		path = "/" + path
@@ -54,35 +470,117 @@ func (c *Client) concatUserPath(path string) string {
 	return fmt.Sprintf("/users/%s%s", c.UserID, path)
 }
 
+// APIVersion returns API version used by the client for given resource
+// Resource is first path segment after user id (like "calls", "messages", "phoneNumbers") or "messagesV2" for V2 messaging methods
+// example: version := api.APIVersion("calls") // "v1"
+func (c *Client) APIVersion(resource string) string {
+	if version, ok := c.APIVersions[resource]; ok {
+		return version
+	}
+	if version, ok := defaultAPIVersions[resource]; ok {
+		return version
+	}
+	return APIVersion1
+}
+
+// resourceOfPath returns resource name of API path (with or without user part)
+func (c *Client) resourceOfPath(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	path = strings.TrimPrefix(path, fmt.Sprintf("users/%s/", c.UserID))
+	if index := strings.IndexAny(path, "/?"); index >= 0 {
+		path = path[:index]
+	}
+	return path
+}
+
 func (c *Client) prepareURL(path string, version string) string {
 	if path[0] != '/' {
 		path = "/" + path
 	}
-	return fmt.Sprintf("%s/%s%s", c.APIEndPoint, version, path)
+	return fmt.Sprintf("%s%s/%s%s", c.APIEndPoint, c.basePathPrefix(), version, path)
+}
+
+// basePathPrefix returns BasePathPrefix with leading and without trailing slash (or empty string)
+func (c *Client) basePathPrefix() string {
+	prefix := strings.Trim(c.BasePathPrefix, "/")
+	if prefix == "" {
+		return ""
+	}
+	return "/" + prefix
 }
 
 func (c *Client) createRequest(method, path string, version string) (*http.Request, error) {
-	request, err := http.NewRequest(method, c.prepareURL(path, version), nil)
+	overriddenMethod := ""
+	if c.MethodOverrideHeader != "" && method != http.MethodGet && method != http.MethodHead && method != http.MethodPost {
+		overriddenMethod, method = method, http.MethodPost
+	}
+	request, err := http.NewRequestWithContext(c.context(), method, c.prepareURL(path, version), nil)
 	if err != nil {
 		return nil, err
 	}
-	request.SetBasicAuth(c.APIToken, c.APISecret)
+	if overriddenMethod != "" {
+		request.Header.Set(c.MethodOverrideHeader, overriddenMethod)
+	}
+	request.SetBasicAuth(c.credentials())
 	request.Header.Set("Accept", "application/json")
-	request.Header.Set("User-Agent", fmt.Sprintf("go-bandwidth/v%s", Version))
+	userAgent := c.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+	request.Header.Set("User-Agent", userAgent)
 	return request, nil
 }
 
+var timeNow = time.Now
+
+// parseTime decodes timestamp of API resource (RFC 3339)
+// It returns zero time for empty or malformed values
+func parseTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// parseRateLimitReset converts value of X-RateLimit-Reset header (server unix time in milliseconds) to local time (rounded up to next second)
+// If the response has Date header the reset time is computed relative to server time, so local clock skew doesn't matter.
+func parseRateLimitReset(headers http.Header) time.Time {
+	reset, _ := strconv.ParseInt(headers.Get("X-RateLimit-Reset"), 10, 64)
+	serverTime, err := http.ParseTime(headers.Get("Date"))
+	if err != nil {
+		return time.Unix(int64((reset/1000)+1), 0)
+	}
+	delta := time.Duration(reset-serverTime.UnixNano()/int64(time.Millisecond)) * time.Millisecond
+	return timeNow().Add(delta).Truncate(time.Second).Add(time.Second)
+}
+
+// maxDrainSize limits count of unread bytes of response body which are discarded before closing
+// (bigger rests are not worth reading, the connection is just not reused then)
+const maxDrainSize = 256 << 10
+
+// drainAndClose reads rest of the response body and closes it, so the connection can be reused
+func drainAndClose(body io.ReadCloser) {
+	io.Copy(ioutil.Discard, io.LimitReader(body, maxDrainSize))
+	body.Close()
+}
+
 func (c *Client) checkResponse(response *http.Response, responseBody interface{}) (interface{}, http.Header, error) {
-	defer response.Body.Close()
+	// the body is drained on all return paths (including early returns before reading it)
+	defer drainAndClose(response.Body)
+	c.trackRateLimit(response.Header)
 	body := responseBody
 	if body == nil {
 		body = map[string]interface{}{}
 	}
-	rawJSON, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return nil, nil, err
-	}
 	if response.StatusCode >= 200 && response.StatusCode < 400 {
+		rawJSON, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return nil, nil, err
+		}
 		if len(rawJSON) > 0 {
 			err = json.Unmarshal([]byte(rawJSON), &body)
 			if err != nil {
@@ -92,101 +590,409 @@ func (c *Client) checkResponse(response *http.Response, responseBody interface{}
 		return body, response.Header, nil
 	}
 	if response.StatusCode == 429 {
-		reset, _ := strconv.ParseInt(response.Header.Get("X-RateLimit-Reset"), 10, 64)
-		return nil, nil, &RateLimitError{Reset: time.Unix(int64((reset/1000)+1), 0)}
+		return nil, nil, &RateLimitError{Reset: parseRateLimitReset(response.Header)}
+	}
+	return nil, nil, c.readAPIError(response)
+}
+
+// maxErrorBodySize returns MaxErrorBodySize or its default value
+func (c *Client) maxErrorBodySize() int64 {
+	if c.MaxErrorBodySize <= 0 {
+		return DefaultMaxErrorBodySize
 	}
+	return c.MaxErrorBodySize
+}
+
+// readAPIError reads error response (truncated to Client.MaxErrorBodySize)
+// Code and message are filled for JSON object bodies, other bodies (like arrays or plain text) are kept in RawBody only.
+func (c *Client) readAPIError(response *http.Response) error {
+	maxSize := c.maxErrorBodySize()
+	text, err := ioutil.ReadAll(io.LimitReader(response.Body, maxSize+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(text)) > maxSize {
+		return &APIError{StatusCode: response.StatusCode, RawBody: string(text[:maxSize]), Truncated: true}
+	}
+	apiErr := &APIError{StatusCode: response.StatusCode, RawBody: string(text)}
 	errorBody := make(map[string]interface{})
-	if len(rawJSON) > 0 {
-		err = json.Unmarshal([]byte(rawJSON), &errorBody)
-		if err != nil {
-			return nil, nil, err
+	if json.Unmarshal(text, &errorBody) == nil {
+		apiErr.Code, apiErr.Message = errorField(errorBody, "code"), errorField(errorBody, "message")
+	}
+	return apiErr
+}
+
+// queryParamsField is name of query struct field with raw query parameters
+const queryParamsField = "QueryParams"
+
+// encodeQuery converts map[string]string or pointer to query struct to query parameters
+// Parameter names are taken from json tags of the fields (fields with tag "-" and unexported fields are skipped)
+// or derived from field names (BridgeID -> bridgeId) for untagged fields.
+// Fields with default values are ignored unless they have json tag without omitempty. Values of QueryParams field
+// are added last (see QueryParams).
+func encodeQuery(data interface{}) url.Values {
+	query := make(url.Values)
+	if data == nil {
+		return query
+	}
+	if item, ok := data.(map[string]string); ok {
+		for key, value := range item {
+			query.Set(key, value)
+		}
+		return query
+	}
+	structValue := reflect.ValueOf(data)
+	if structValue.IsNil() {
+		return query
+	}
+	structValue = structValue.Elem()
+	structType := structValue.Type()
+	var rawParams map[string]string
+	fieldCount := structType.NumField()
+	for i := 0; i < fieldCount; i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			// unexported field
+			continue
+		}
+		if field.Name == queryParamsField {
+			switch params := structValue.Field(i).Interface().(type) {
+			case QueryParams:
+				rawParams = params
+			case map[string]string:
+				rawParams = params
+			}
+			continue
+		}
+		name, omitEmpty := queryParamName(field)
+		if name == "" {
+			continue
 		}
+		fieldValue := structValue.Field(i)
+		if fieldValue.Kind() == reflect.Ptr {
+			// optional field: nil is omitted, set value is sent even if it is zero (like false or 0)
+			if fieldValue.IsNil() {
+				continue
+			}
+			fieldValue = fieldValue.Elem()
+		} else if omitEmpty && fieldValue.IsZero() {
+			//ignore fields with default values
+			continue
+		}
+		query.Set(name, fmt.Sprintf("%v", fieldValue.Interface()))
+	}
+	for key, value := range rawParams {
+		query.Set(key, value)
+	}
+	return query
+}
+
+// QueryParams are raw query parameters of list requests (QueryParams field of query structs like GetCallsQuery)
+// They are added after the typed fields of the query, so they override values of the fields with the same parameter name.
+// Use them for parameters which have no field in the query struct.
+// example: calls, err := api.GetCalls(&bandwidth.GetCallsQuery{Size: 10, QueryParams: bandwidth.QueryParams{"size": "5"}})
+type QueryParams map[string]string
+
+// Bool returns pointer to the value (for optional bool fields of query structs)
+// example: query := &bandwidth.GetAvailableNumberQuery{AreaCode: "919", InLocalCallingArea: bandwidth.Bool(false)}
+func Bool(value bool) *bool {
+	return &value
+}
+
+// Int returns pointer to the value (for optional int fields of query structs)
+func Int(value int) *int {
+	return &value
+}
+
+// String returns pointer to the value (for optional string fields of query structs)
+func String(value string) *string {
+	return &value
+}
+
+// queryParamName returns name of query parameter for the struct field (empty for skipped fields)
+// and whether the parameter should be omitted for default value of the field
+func queryParamName(field reflect.StructField) (string, bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return strings.Replace(strings.ToLower(field.Name[:1])+field.Name[1:], "ID", "Id", -1), true
+	}
+	if tag == "-" {
+		return "", false
 	}
-	message := errorBody["message"]
-	if message == nil {
-		message = errorBody["code"]
+	parts := strings.Split(tag, ",")
+	omitEmpty := false
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
 	}
-	if message == nil {
-		return nil, nil, fmt.Errorf("Http code %d", response.StatusCode)
+	name := parts[0]
+	if name == "" {
+		// tag with options only (like ",omitempty") keeps derived name
+		name, _ = queryParamName(reflect.StructField{Name: field.Name})
 	}
-	return nil, nil, errors.New(message.(string))
+	return name, omitEmpty
 }
 
-func (c *Client) makeRequestInternal(method, path string, version string, data ...interface{}) (interface{}, http.Header, error) {
-	request, err := c.createRequest(method, path, version)
-	var responseBody interface{}
-	treatDataAsQuery := false
+// clearFieldsField is name of request data struct field with list of fields which should be sent as null
+const clearFieldsField = "ClearFields"
+
+// marshalRequestData encodes request data to JSON
+// Fields of data struct listed in its ClearFields field are sent as explicit null (only fields with tag clearable:"true" can be cleared).
+func marshalRequestData(data interface{}) ([]byte, error) {
+	rawJSON, err := json.Marshal(data)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
+	}
+	structValue := reflect.ValueOf(data)
+	if structValue.Kind() != reflect.Ptr || structValue.IsNil() || structValue.Elem().Kind() != reflect.Struct {
+		return rawJSON, nil
+	}
+	structValue = structValue.Elem()
+	clearFieldsValue := structValue.FieldByName(clearFieldsField)
+	if !clearFieldsValue.IsValid() {
+		return rawJSON, nil
+	}
+	clearFields, _ := clearFieldsValue.Interface().([]string)
+	if len(clearFields) == 0 {
+		return rawJSON, nil
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(rawJSON, &fields); err != nil {
+		return nil, err
+	}
+	for _, name := range clearFields {
+		field, ok := structValue.Type().FieldByName(name)
+		if !ok || field.Tag.Get("clearable") != "true" {
+			return nil, fmt.Errorf("Field %s of %s can't be cleared", name, structValue.Type().Name())
+		}
+		fields[strings.Split(field.Tag.Get("json"), ",")[0]] = json.RawMessage("null")
 	}
+	return json.Marshal(fields)
+}
+
+// makeRequestVersion makes request to given API version (resources declare their versions via defaultAPIVersions)
+// data are optional response body receiver, request body (or query) and flag to send request body as query
+func (c *Client) makeRequestVersion(version, method, path string, data ...interface{}) (interface{}, http.Header, error) {
+	var responseBody interface{}
+	treatDataAsQuery := false
 	if len(data) > 0 {
 		responseBody = data[0]
 	}
 	if len(data) > 2 {
 		treatDataAsQuery = data[2].(bool)
 	}
+	var rawQuery string
+	var rawJSON []byte
 	if len(data) > 1 {
 		if method == "GET" || treatDataAsQuery {
-			var item map[string]string
-			if data[1] == nil {
-				item = make(map[string]string)
-			} else {
-				var ok bool
-				item, ok = data[1].(map[string]string)
-				if !ok {
-					item = make(map[string]string)
-					structType := reflect.TypeOf(data[1]).Elem()
-					structValue := reflect.ValueOf(data[1])
-					if !structValue.IsNil() {
-						structValue = structValue.Elem()
-						fieldCount := structType.NumField()
-						for i := 0; i < fieldCount; i++ {
-							fieldName := structType.Field(i).Name
-							fieldValue := structValue.Field(i).Interface()
-							if fieldValue == reflect.Zero(structType.Field(i).Type).Interface() {
-								//ignore fields with default values
-								continue
-							}
-							item[strings.Replace(strings.ToLower(string(fieldName[0]))+fieldName[1:], "ID", "Id", -1)] = fmt.Sprintf("%v", fieldValue)
-						}
-					}
-				}
-			}
-			query := make(url.Values)
-			for key, value := range item {
-				query[key] = []string{value}
-			}
-			request.URL.RawQuery = query.Encode()
+			rawQuery = encodeQuery(data[1]).Encode()
 		} else {
-			request.Header.Set("Content-Type", "application/json")
-			rawJSON, err := json.Marshal(data[1])
+			var err error
+			rawJSON, err = marshalRequestData(data[1])
 			if err != nil {
 				return nil, nil, err
 			}
+		}
+	}
+	for attempt := 0; ; attempt++ {
+		// the request is created for each attempt because the body is consumed by sending
+		request, err := c.createRequest(method, path, version)
+		if err != nil {
+			return nil, nil, err
+		}
+		if rawQuery != "" {
+			request.URL.RawQuery = rawQuery
+		}
+		if rawJSON != nil {
+			request.Header.Set("Content-Type", "application/json")
 			request.Body = nopCloser{bytes.NewReader(rawJSON)}
 		}
+		result, headers, err := c.sendRequest(request, rawJSON, responseBody)
+		rateLimitErr, ok := err.(*RateLimitError)
+		if !ok || attempt >= c.MaxRateLimitRetries {
+			return result, headers, err
+		}
+		if err := sleepContext(request.Context(), rateLimitErr.RetryAfter()+rateLimitRetryJitter()); err != nil {
+			return nil, nil, err
+		}
 	}
-	response, err := c.HTTPClient.Do(request)
+}
+
+func (c *Client) sendRequest(request *http.Request, requestBody []byte, responseBody interface{}) (interface{}, http.Header, error) {
+	if err := c.waitForRateLimit(request.Context()); err != nil {
+		return nil, nil, err
+	}
+	// the clock starts after own slow down of the client, so the duration is latency of the request only
+	start := time.Now()
+	response, done, err := c.doRequest(request)
 	if err != nil {
+		if c.RequestLogger != nil {
+			c.RequestLogger(request.Method, request.URL.String(), requestBody, 0, nil, time.Since(start))
+		}
 		return nil, nil, err
 	}
+	defer done()
+	if c.RequestLogger != nil {
+		if err := c.logResponse(request, requestBody, response, time.Since(start)); err != nil {
+			return nil, nil, err
+		}
+	}
 	return c.checkResponse(response, responseBody)
 }
 
+// logResponse passes the request and its response to RequestLogger
+// Response body is buffered, so it can be read again (bodies of error responses are buffered up to Client.MaxErrorBodySize).
+func (c *Client) logResponse(request *http.Request, requestBody []byte, response *http.Response, duration time.Duration) error {
+	var reader io.Reader = response.Body
+	if response.StatusCode >= 400 {
+		// one extra byte lets checkResponse detect truncated body
+		reader = io.LimitReader(response.Body, c.maxErrorBodySize()+1)
+	}
+	body, err := ioutil.ReadAll(reader)
+	drainAndClose(response.Body)
+	if err != nil {
+		return err
+	}
+	response.Body = nopCloser{bytes.NewReader(body)}
+	c.RequestLogger(request.Method, request.URL.String(), requestBody, response.StatusCode, body, duration)
+	return nil
+}
+
+// doRequest sends the request (callers wait for rate limit before)
+// It returns the response and function which should be called after handling of the response (it completes timing of the request) or error
+func (c *Client) doRequest(request *http.Request) (*http.Response, func(), error) {
+	done := func() {}
+	if target := timingOfContext(request.Context()); c.CollectTiming || target != nil {
+		var timer *requestTimer
+		request, timer = traceRequest(request)
+		done = func() { c.finishTiming(timer, target) }
+	}
+	response, err := c.HTTPClient.Do(request)
+	if err != nil {
+		done()
+		return nil, nil, &TransportError{Err: err}
+	}
+	return response, done, nil
+}
+
+// makeRawRequest sends the request with raw body of given content type (body can be nil) and doesn't decode the response.
+// Body of returned response should be closed by the caller. Error responses are returned as *APIError or *RateLimitError.
+// It returns the response or error
+func (c *Client) makeRawRequest(method, path string, version string, body io.Reader, contentType string) (*http.Response, error) {
+	request, err := c.createRequest(method, path, version)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		if closer, ok := body.(io.ReadCloser); ok {
+			request.Body = closer
+		} else {
+			request.Body = ioutil.NopCloser(body)
+		}
+		request.Header.Set("Content-Type", contentType)
+	}
+	if err := c.waitForRateLimit(request.Context()); err != nil {
+		if request.Body != nil {
+			request.Body.Close()
+		}
+		return nil, err
+	}
+	start := time.Now()
+	// raw bodies (media files) are not logged
+	logRequest := func(statusCode int, responseBody []byte) {
+		if c.RequestLogger != nil {
+			c.RequestLogger(request.Method, request.URL.String(), nil, statusCode, responseBody, time.Since(start))
+		}
+	}
+	response, done, err := c.doRequest(request)
+	if err != nil {
+		logRequest(0, nil)
+		return nil, err
+	}
+	defer done()
+	c.trackRateLimit(response.Header)
+	if response.StatusCode < 400 {
+		logRequest(response.StatusCode, nil)
+		return response, nil
+	}
+	defer drainAndClose(response.Body)
+	if response.StatusCode == 429 {
+		logRequest(response.StatusCode, nil)
+		return nil, &RateLimitError{Reset: parseRateLimitReset(response.Header)}
+	}
+	err = c.readAPIError(response)
+	var errorBody []byte
+	if apiErr, ok := err.(*APIError); ok {
+		errorBody = []byte(apiErr.RawBody)
+	}
+	logRequest(response.StatusCode, errorBody)
+	return nil, err
+}
+
+// makeRequest makes request to API version of the resource of the path
 func (c *Client) makeRequest(method, path string, data ...interface{}) (interface{}, http.Header, error) {
-	return c.makeRequestInternal(method, path, "v1", data...)
+	return c.makeRequestVersion(c.APIVersion(c.resourceOfPath(path)), method, path, data...)
 }
 
+// makeRequestV2 makes request to API version of V2 messaging methods
 func (c *Client) makeRequestV2(method, path string, data ...interface{}) (interface{}, http.Header, error) {
-	return c.makeRequestInternal(method, path, "v2", data...)
+	return c.makeRequestVersion(c.APIVersion(messagesV2Resource), method, path, data...)
+}
+
+// getTyped requests a single resource of type T
+// It returns T instance or error
+func getTyped[T any](c *Client, path string, query interface{}) (*T, error) {
+	result, _, err := c.makeRequest(http.MethodGet, path, new(T), query)
+	if err != nil {
+		return nil, err
+	}
+	item, ok := result.(*T)
+	if !ok {
+		return nil, fmt.Errorf("Unexpected type %T of the response", result)
+	}
+	return item, nil
+}
+
+// listTyped requests a list of resources of type T
+// It returns list of T instances or error
+func listTyped[T any](c *Client, path string, query interface{}) ([]*T, error) {
+	list, err := getTyped[[]*T](c, path, query)
+	if err != nil {
+		return nil, err
+	}
+	return *list, nil
+}
+
+// updateResourceAndFetch posts changes of a resource and then reads its actual state into out
+// (update methods of the API return empty body)
+func (c *Client) updateResourceAndFetch(path string, data interface{}, out interface{}) error {
+	if _, _, err := c.makeRequest(http.MethodPost, path, nil, data); err != nil {
+		return err
+	}
+	_, _, err := c.makeRequest(http.MethodGet, path, out)
+	return err
 }
 
 func getIDFromLocationHeader(headers http.Header) string {
 	return getIDFromLocation(headers.Get("Location"))
 }
 
+// getCreatedID returns ID of created resource from Location header (or from "id" field of response body if there is no Location header)
+func getCreatedID(result interface{}, headers http.Header) (string, error) {
+	if id := getIDFromLocationHeader(headers); id != "" {
+		return id, nil
+	}
+	if body, ok := result.(map[string]interface{}); ok {
+		if id, ok := body["id"].(string); ok && id != "" {
+			return id, nil
+		}
+	}
+	return "", errors.New("Missing ID of created resource (no Location header in the response)")
+}
+
 func getIDFromLocation(location string) string {
-	list := strings.Split(location, "/")
+	list := strings.Split(strings.TrimRight(location, "/"), "/")
 	l := len(list)
 	if l == 0 {
 		return ""
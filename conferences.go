@@ -1,8 +1,10 @@
 package bandwidth
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 const conferencesPath = "conferences"
@@ -39,21 +41,17 @@ type CreateConferenceData struct {
 // CreateConference creates a new conference
 // It returns ID of creeated conference
 func (api *Client) CreateConference(data *CreateConferenceData) (string, error) {
-	_, headers, err := api.makeRequest(http.MethodPost, api.concatUserPath(conferencesPath), nil, data)
+	result, headers, err := api.makeRequest(http.MethodPost, api.concatUserPath(conferencesPath), nil, data)
 	if err != nil {
 		return "", err
 	}
-	return getIDFromLocationHeader(headers), nil
+	return getCreatedID(result, headers)
 }
 
 // GetConference returns information about a conference
 //It return Conference instance for found conference or error
 func (api *Client) GetConference(id string) (*Conference, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s", api.concatUserPath(conferencesPath), id), &Conference{})
-	if err != nil {
-		return nil, err
-	}
-	return result.(*Conference), nil
+	return getTyped[Conference](api, fmt.Sprintf("%s/%s", api.concatUserPath(conferencesPath), id), nil)
 }
 
 // UpdateConferenceData struct
@@ -78,6 +76,11 @@ func (api *Client) UpdateConference(id string, data *UpdateConferenceData) error
 // PlayAudioToConference plays an audio or speak a sentence in a conference
 // It returns error object
 func (api *Client) PlayAudioToConference(id string, data *PlayAudioData) error {
+	if data != nil {
+		if err := data.validate(); err != nil {
+			return err
+		}
+	}
 	_, _, err := api.makeRequest(http.MethodPost, fmt.Sprintf("%s/%s/%s", api.concatUserPath(conferencesPath), id, "audio"), nil, data)
 	return err
 }
@@ -93,6 +96,19 @@ type ConferenceMember struct {
 	Mute        bool   `json:"mute"`
 	JoinTone    bool   `json:"joinTone"`
 	LeavingTone bool   `json:"leavingTone"`
+	// JoinTime and LeaveTime are parsed AddedTime and RemovedTime (LeaveTime is zero while the member is active)
+	JoinTime  time.Time `json:"-"`
+	LeaveTime time.Time `json:"-"`
+}
+
+// UnmarshalJSON decodes the member and fills its typed times
+func (m *ConferenceMember) UnmarshalJSON(data []byte) error {
+	type rawConferenceMember ConferenceMember
+	if err := json.Unmarshal(data, (*rawConferenceMember)(m)); err != nil {
+		return err
+	}
+	m.JoinTime, m.LeaveTime = parseTime(m.AddedTime), parseTime(m.RemovedTime)
+	return nil
 }
 
 // GetCallID returns call ID of member
@@ -100,6 +116,22 @@ func (m *ConferenceMember) GetCallID() string {
 	return getIDFromLocation(m.Call)
 }
 
+// Duration returns time spent by the member in the conference (until now for active members)
+// It returns zero if join time is unknown
+func (m *ConferenceMember) Duration() time.Duration {
+	if m.JoinTime.IsZero() {
+		return 0
+	}
+	end := m.LeaveTime
+	if end.IsZero() {
+		end = timeNow()
+	}
+	if end.Before(m.JoinTime) {
+		return 0
+	}
+	return end.Sub(m.JoinTime)
+}
+
 // CreateConferenceMemberData struct
 type CreateConferenceMemberData struct {
 	CallID      string `json:"callId"`
@@ -112,31 +144,23 @@ type CreateConferenceMemberData struct {
 // CreateConferenceMember creates a new conference member
 // It returns ID of created member
 func (api *Client) CreateConferenceMember(id string, data *CreateConferenceMemberData) (string, error) {
-	_, headers, err := api.makeRequest(http.MethodPost, fmt.Sprintf("%s/%s/%s", api.concatUserPath(conferencesPath), id, "members"), nil, data)
+	result, headers, err := api.makeRequest(http.MethodPost, fmt.Sprintf("%s/%s/%s", api.concatUserPath(conferencesPath), id, "members"), nil, data)
 	if err != nil {
 		return "", err
 	}
-	return getIDFromLocationHeader(headers), nil
+	return getCreatedID(result, headers)
 }
 
 // GetConferenceMembers returns  the list of conference members
 // It returns list of ConferenceMember or error
 func (api *Client) GetConferenceMembers(id string) ([]*ConferenceMember, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s/%s", api.concatUserPath(conferencesPath), id, "members"), &[]*ConferenceMember{})
-	if err != nil {
-		return nil, err
-	}
-	return *(result.(*[]*ConferenceMember)), nil
+	return listTyped[ConferenceMember](api, fmt.Sprintf("%s/%s/%s", api.concatUserPath(conferencesPath), id, "members"), nil)
 }
 
 // GetConferenceMember returns information about one conference member
 // It returns ConferenceMember instance for found instance or error
 func (api *Client) GetConferenceMember(id string, memberID string) (*ConferenceMember, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s/%s/%s", api.concatUserPath(conferencesPath), id, "members", memberID), &ConferenceMember{})
-	if err != nil {
-		return nil, err
-	}
-	return result.(*ConferenceMember), nil
+	return getTyped[ConferenceMember](api, fmt.Sprintf("%s/%s/%s/%s", api.concatUserPath(conferencesPath), id, "members", memberID), nil)
 }
 
 // UpdateConferenceMemberData struct
@@ -158,6 +182,11 @@ func (api *Client) UpdateConferenceMember(id string, memberID string, data *Upda
 // PlayAudioToConferenceMember plays an audio or speak a sentence to a conference member
 // It returns error object
 func (api *Client) PlayAudioToConferenceMember(id string, memberID string, data *PlayAudioData) error {
+	if data != nil {
+		if err := data.validate(); err != nil {
+			return err
+		}
+	}
 	_, _, err := api.makeRequest(http.MethodPost, fmt.Sprintf("%s/%s/%s/%s/%s", api.concatUserPath(conferencesPath), id, "members", memberID, "audio"), nil, data)
 	return err
 }
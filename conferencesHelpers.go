@@ -1,5 +1,9 @@
 package bandwidth
 
+import (
+	"sort"
+)
+
 // TerminateConference terminates a  conference
 // example: api.TerminateConference("conferenceId")
 func (api *Client) TerminateConference(id string) error{
@@ -29,3 +33,56 @@ func (api *Client) MuteConferenceMember(id string, memberID string, mute bool) e
 func (api *Client) HoldConferenceMember(id string, memberID string, hold bool) error{
 	return api.UpdateConferenceMember(id, memberID, &UpdateConferenceMemberData{Hold: hold})
 }
+
+// GetNumberConferences returns conferences which calls from or to the number took part in (sorted by creation time)
+// The API has no such endpoint, so all calls from and to the number are listed (one request per page of 1000 calls in each direction)
+// and conferences of the calls are requested in parallel (one request per conference). Cache the result if you need it often.
+// It returns list of Conference instances or error
+// example: conferences, err := api.GetNumberConferences("+19195551212")
+func (api *Client) GetNumberConferences(number string) ([]*Conference, error) {
+	ids := []string{}
+	seen := map[string]bool{}
+	for _, query := range []*GetCallsQuery{&GetCallsQuery{From: number, Size: maxPageSize}, &GetCallsQuery{To: number, Size: maxPageSize}} {
+		calls, err := api.GetAllCalls(query)
+		if err != nil {
+			return nil, err
+		}
+		for _, call := range calls {
+			if call.ConferenceID != "" && !seen[call.ConferenceID] {
+				seen[call.ConferenceID] = true
+				ids = append(ids, call.ConferenceID)
+			}
+		}
+	}
+	conferences := make([]*Conference, len(ids))
+	errs := api.runBatch(len(ids), func(i int) error {
+		conference, err := api.GetConference(ids[i])
+		conferences[i] = conference
+		return err
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(conferences, func(i, j int) bool {
+		return parseTime(conferences[i].CreatedTime).Before(parseTime(conferences[j].CreatedTime))
+	})
+	return conferences, nil
+}
+
+// SortConferenceMembers sorts members in join order (members with the same join time are ordered by id, members without join time go last)
+// example: members, err := api.GetConferenceMembers("conferenceId")
+// bandwidth.SortConferenceMembers(members)
+func SortConferenceMembers(members []*ConferenceMember) {
+	sort.SliceStable(members, func(i, j int) bool {
+		a, b := members[i], members[j]
+		if a.JoinTime.IsZero() != b.JoinTime.IsZero() {
+			return !a.JoinTime.IsZero()
+		}
+		if !a.JoinTime.Equal(b.JoinTime) {
+			return a.JoinTime.Before(b.JoinTime)
+		}
+		return a.ID < b.ID
+	})
+}
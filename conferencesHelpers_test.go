@@ -3,6 +3,7 @@ package bandwidth
 import (
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestTerminateConference(t *testing.T) {
@@ -64,3 +65,62 @@ func TestHoldConferenceMember(t *testing.T) {
 		t.Error("Failed call of HoldConferenceMember()")
 	}
 }
+
+func TestGetNumberConferences(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls?from=%2B19195551212&size=1000",
+		Method:        http.MethodGet,
+		HeadersToSend: map[string]string{"Link": `<https://api.catapult.inetwork.com/v1/users/userId/calls?from=%2B19195551212&page=1&size=1000>; rel="next"`},
+		ContentToSend: `[{"id": "1", "conferenceId": "c2"}, {"id": "2"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls?from=%2B19195551212&page=1&size=1000",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "3", "conferenceId": "c1"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls?size=1000&to=%2B19195551212",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "4", "conferenceId": "c2"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/conferences/c1",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "c1", "createdTime": "2017-01-10T10:00:00Z"}`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/conferences/c2",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "c2", "createdTime": "2017-01-11T10:00:00Z"}`}})
+	defer server.Close()
+	conferences, err := api.GetNumberConferences("+19195551212")
+	if err != nil {
+		t.Fatal("Failed call of GetNumberConferences()")
+	}
+	expect(t, len(conferences), 2)
+	expect(t, conferences[0].ID, "c1")
+	expect(t, conferences[1].ID, "c2")
+}
+
+func TestGetNumberConferencesFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls?from=%2B19195551212&size=1000",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "1", "conferenceId": "c1"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls?size=1000&to=%2B19195551212",
+		Method:        http.MethodGet,
+		ContentToSend: `[]`}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/conferences/c1",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusNotFound}})
+	defer server.Close()
+	shouldFail(t, func() (interface{}, error) { return api.GetNumberConferences("+19195551212") })
+}
+
+func TestSortConferenceMembers(t *testing.T) {
+	start := time.Date(2013, 7, 12, 15, 54, 47, 0, time.UTC)
+	members := []*ConferenceMember{
+		&ConferenceMember{ID: "4"},
+		&ConferenceMember{ID: "3", JoinTime: start.Add(time.Minute)},
+		&ConferenceMember{ID: "2", JoinTime: start},
+		&ConferenceMember{ID: "1", JoinTime: start},
+	}
+	SortConferenceMembers(members)
+	ids := make([]string, len(members))
+	for i, member := range members {
+		ids[i] = member.ID
+	}
+	expect(t, ids, []string{"1", "2", "3", "4"})
+}
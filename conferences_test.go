@@ -1,8 +1,10 @@
 package bandwidth
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestCreateConference(t *testing.T) {
@@ -37,7 +39,9 @@ func TestGetConference(t *testing.T) {
 		Method:       http.MethodGet,
 		ContentToSend: `{
 			"id": "{conferenceId}",
-			"state": "completed"
+			"state": "completed",
+			"createdTime": "2013-07-12T15:22:47Z",
+			"completedTime": "2013-07-12T15:23:50Z"
 		}`}})
 	defer server.Close()
 	result, err := api.GetConference("123")
@@ -46,6 +50,8 @@ func TestGetConference(t *testing.T) {
 		return
 	}
 	expect(t, result.ID, "{conferenceId}")
+	expect(t, result.CreatedTime, "2013-07-12T15:22:47Z")
+	expect(t, result.CompletedTime, "2013-07-12T15:23:50Z")
 }
 
 func TestGetConferenceFail(t *testing.T) {
@@ -117,7 +123,9 @@ func TestGetConferenceMember(t *testing.T) {
 		PathAndQuery: "/v1/users/userId/conferences/123/members/456",
 		Method:       http.MethodGet,
 		ContentToSend: `{
-			"id": "{member1}"
+			"id": "{member1}",
+			"addedTime": "2013-07-12T15:54:47Z",
+			"removedTime": "2013-07-12T15:56:12Z"
 		}`}})
 	defer server.Close()
 	result, err := api.GetConferenceMember("123", "456")
@@ -126,6 +134,23 @@ func TestGetConferenceMember(t *testing.T) {
 		return
 	}
 	expect(t, result.ID, "{member1}")
+	expect(t, result.AddedTime, "2013-07-12T15:54:47Z")
+	expect(t, result.RemovedTime, "2013-07-12T15:56:12Z")
+	expect(t, result.JoinTime, time.Date(2013, 7, 12, 15, 54, 47, 0, time.UTC))
+	expect(t, result.LeaveTime, time.Date(2013, 7, 12, 15, 56, 12, 0, time.UTC))
+	expect(t, result.Duration(), 85*time.Second)
+}
+
+func TestConferenceMemberDurationOfActiveMember(t *testing.T) {
+	timeNow = func() time.Time { return time.Date(2013, 7, 12, 15, 55, 47, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+	member := &ConferenceMember{}
+	expectNil(t, json.Unmarshal([]byte(`{"id": "1", "state": "active", "addedTime": "2013-07-12T15:54:47Z"}`), member))
+	expect(t, member.LeaveTime.IsZero(), true)
+	expect(t, member.Duration(), time.Minute)
+	member = &ConferenceMember{}
+	expectNil(t, json.Unmarshal([]byte(`{"id": "1", "addedTime": ""}`), member))
+	expect(t, member.Duration(), time.Duration(0))
 }
 
 func TestGetConferenceMemberFail(t *testing.T) {
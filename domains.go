@@ -7,6 +7,9 @@ import (
 
 const domainsPath = "domains"
 
+// maximal page size allowed by /domains and /domains/{id}/endpoints
+const maxDomainsPageSize = 100
+
 // Domain struct
 type Domain struct {
 	ID          string `json:"id"`
@@ -17,20 +20,30 @@ type Domain struct {
 // GetDomainsQuery is optional parameters of GetDomains()
 type GetDomainsQuery struct {
 	Size int
+	// QueryParams are raw query parameters (see QueryParams type)
+	QueryParams QueryParams
 }
 
 // GetDomains returns  a list of the domains that have been created
 // It returns list of Domain instances or error
 func (api *Client) GetDomains(query ...*GetDomainsQuery) ([]*Domain, error) {
+	list, _, err := api.GetDomainsPage(query...)
+	return list, err
+}
+
+// GetDomainsPage returns a page of domains like GetDomains() with pagination data of the page
+// It returns list of Domain instances, Pagination instance or error
+// example: list, pagination, err := api.GetDomainsPage(&bandwidth.GetDomainsQuery{Size: 10})
+func (api *Client) GetDomainsPage(query ...*GetDomainsQuery) ([]*Domain, *Pagination, error) {
 	var options *GetDomainsQuery
 	if len(query) > 0 {
 		options = query[0]
 	}
-	result, _, err := api.makeRequest(http.MethodGet, api.concatUserPath(domainsPath), &[]*Domain{}, options)
+	result, pagination, err := api.getListPage(api.concatUserPath(domainsPath), &[]*Domain{}, options)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return *(result.(*[]*Domain)), nil
+	return *(result.(*[]*Domain)), pagination, nil
 }
 
 // CreateDomainData struct
@@ -42,11 +55,11 @@ type CreateDomainData struct {
 // CreateDomain creates a new domain
 // It returns ID of created domain or error
 func (api *Client) CreateDomain(data *CreateDomainData) (string, error) {
-	_, headers, err := api.makeRequest(http.MethodPost, api.concatUserPath(domainsPath), nil, data)
+	result, headers, err := api.makeRequest(http.MethodPost, api.concatUserPath(domainsPath), nil, data)
 	if err != nil {
 		return "", err
 	}
-	return getIDFromLocationHeader(headers), nil
+	return getCreatedID(result, headers)
 }
 
 // DeleteDomain removes a domain
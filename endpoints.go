@@ -47,40 +47,46 @@ type DomainEndpointToken struct {
 type GetDomainEndpointsQuery struct {
 	Page int
 	Size int
+	// QueryParams are raw query parameters (see QueryParams type)
+	QueryParams QueryParams
 }
 
 // GetDomainEndpoints returns list of all endpoints for a domain
 // It returns list of DomainEndpoint instances or error
 func (api *Client) GetDomainEndpoints(id string, query ...*GetDomainEndpointsQuery) ([]*DomainEndpoint, error) {
+	list, _, err := api.GetDomainEndpointsPage(id, query...)
+	return list, err
+}
+
+// GetDomainEndpointsPage returns a page of endpoints of a domain like GetDomainEndpoints() with pagination data of the page
+// It returns list of DomainEndpoint instances, Pagination instance or error
+// example: list, pagination, err := api.GetDomainEndpointsPage("domainId", &bandwidth.GetDomainEndpointsQuery{Page: 1})
+func (api *Client) GetDomainEndpointsPage(id string, query ...*GetDomainEndpointsQuery) ([]*DomainEndpoint, *Pagination, error) {
 	var options *GetDomainEndpointsQuery
 	if len(query) > 0 {
 		options = query[0]
 	}
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s/%s", api.concatUserPath(domainsPath), id, endpointsPath), &[]*DomainEndpoint{}, options)
+	result, pagination, err := api.getListPage(fmt.Sprintf("%s/%s/%s", api.concatUserPath(domainsPath), id, endpointsPath), &[]*DomainEndpoint{}, options)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return *(result.(*[]*DomainEndpoint)), nil
+	return *(result.(*[]*DomainEndpoint)), pagination, nil
 }
 
 // CreateDomainEndpoint creates a new endpoint for a domain
 // It returns ID of created endpoint or error
 func (api *Client) CreateDomainEndpoint(id string, data *DomainEndpointData) (string, error) {
-	_, headers, err := api.makeRequest(http.MethodPost, fmt.Sprintf("%s/%s/%s", api.concatUserPath(domainsPath), id, endpointsPath), nil, data)
+	result, headers, err := api.makeRequest(http.MethodPost, fmt.Sprintf("%s/%s/%s", api.concatUserPath(domainsPath), id, endpointsPath), nil, data)
 	if err != nil {
 		return "", err
 	}
-	return getIDFromLocationHeader(headers), nil
+	return getCreatedID(result, headers)
 }
 
 // GetDomainEndpoint returns   single enpoint for a domain
 // It returns DomainEndpoint instance or error
 func (api *Client) GetDomainEndpoint(id string, endpointID string) (*DomainEndpoint, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s/%s/%s", api.concatUserPath(domainsPath), id, endpointsPath, endpointID), &DomainEndpoint{})
-	if err != nil {
-		return nil, err
-	}
-	return result.(*DomainEndpoint), nil
+	return getTyped[DomainEndpoint](api, fmt.Sprintf("%s/%s/%s/%s", api.concatUserPath(domainsPath), id, endpointsPath, endpointID), nil)
 }
 
 // DeleteDomainEndpoint removes a endpoint from domain
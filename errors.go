@@ -2,7 +2,6 @@ package bandwidth
 
 import (
 	"fmt"
-	"net/http"
 )
 
 const errorsPath = "errors"
@@ -28,28 +27,34 @@ type ErrorDetail struct {
 type GetErrorsQuery struct {
 	Page int
 	Size int
+	// QueryParams are raw query parameters (see QueryParams type)
+	QueryParams QueryParams
 }
 
 // GetErrors returns list of errors
 // It returns list of Error instances or error
 func (api *Client) GetErrors(query ...*GetErrorsQuery) ([]*Error, error) {
+	list, _, err := api.GetErrorsPage(query...)
+	return list, err
+}
+
+// GetErrorsPage returns a page of errors like GetErrors() with pagination data of the page
+// It returns list of Error instances, Pagination instance or error
+// example: list, pagination, err := api.GetErrorsPage(&bandwidth.GetErrorsQuery{Page: 1})
+func (api *Client) GetErrorsPage(query ...*GetErrorsQuery) ([]*Error, *Pagination, error) {
 	var options *GetErrorsQuery
 	if len(query) > 0 {
 		options = query[0]
 	}
-	result, _, err := api.makeRequest(http.MethodGet, api.concatUserPath(errorsPath), &[]*Error{}, options)
+	result, pagination, err := api.getListPage(api.concatUserPath(errorsPath), &[]*Error{}, options)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return *(result.(*[]*Error)), nil
+	return *(result.(*[]*Error)), pagination, nil
 }
 
 // GetError returns  error by id
 // It return Error instance for found error or error object
 func (api *Client) GetError(id string) (*Error, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s", api.concatUserPath(errorsPath), id), &Error{})
-	if err != nil {
-		return nil, err
-	}
-	return result.(*Error), nil
+	return getTyped[Error](api, fmt.Sprintf("%s/%s", api.concatUserPath(errorsPath), id), nil)
 }
@@ -88,6 +88,7 @@ func TestGetError(t *testing.T) {
 		return
 	}
 	expect(t, result.ID, "{userErrorId2}")
+	expect(t, result.Time, "2012-11-15T01:29:24.512Z")
 }
 
 func TestGetErrorFail(t *testing.T) {
@@ -0,0 +1,115 @@
+package bandwidth
+
+import (
+	"container/list"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// DefaultEventStoreCapacity is count of event keys stored by EventDeduper with default store
+const DefaultEventStoreCapacity = 10000
+
+// EventStore keeps keys of processed events for EventDeduper.
+// Implement it to share processed events between instances (for example using Redis SETNX with expiration)
+type EventStore interface {
+	// MarkProcessed stores the key and returns true if the key has been stored already
+	// It must be atomic (check and store)
+	MarkProcessed(key string) (bool, error)
+}
+
+// MemoryEventStore is in-memory EventStore which keeps last Capacity keys (least recently used keys are removed)
+type MemoryEventStore struct {
+	capacity int
+	mutex    sync.Mutex
+	keys     map[string]*list.Element
+	order    *list.List
+}
+
+// NewMemoryEventStore creates in-memory store of event keys with given capacity
+// DefaultEventStoreCapacity is used for not positive capacity
+func NewMemoryEventStore(capacity int) *MemoryEventStore {
+	if capacity <= 0 {
+		capacity = DefaultEventStoreCapacity
+	}
+	return &MemoryEventStore{capacity: capacity, keys: make(map[string]*list.Element), order: list.New()}
+}
+
+// MarkProcessed stores the key and returns true if the key has been stored already
+func (s *MemoryEventStore) MarkProcessed(key string) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if element, ok := s.keys[key]; ok {
+		s.order.MoveToFront(element)
+		return true, nil
+	}
+	s.keys[key] = s.order.PushFront(key)
+	if s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.keys, oldest.Value.(string))
+	}
+	return false, nil
+}
+
+// EventDeduper detects redelivered callback events (Catapult can send the same event more than once)
+type EventDeduper struct {
+	Store EventStore
+}
+
+// NewEventDeduper creates EventDeduper with given store (in-memory store is used if store is nil)
+// example: deduper := bandwidth.NewEventDeduper(nil)
+func NewEventDeduper(store EventStore) *EventDeduper {
+	if store == nil {
+		store = NewMemoryEventStore(DefaultEventStoreCapacity)
+	}
+	return &EventDeduper{Store: store}
+}
+
+// IsDuplicate marks the event as processed and returns true if it has been processed already
+// Events without id are never treated as duplicates.
+// example: if duplicate, err := deduper.IsDuplicate(event); err == nil && duplicate { return } // already handled
+func (d *EventDeduper) IsDuplicate(event Event) (bool, error) {
+	key := EventKey(event)
+	if key == "" {
+		return false, nil
+	}
+	return d.Store.MarkProcessed(key)
+}
+
+var eventIDFields = []string{"messageId", "recordingId", "transcriptionId", "conferenceId", "memberId", "bridgeId", "callId"}
+
+// EventKey returns unique key of the event built from its type, ids, state and time
+// It returns empty string if the event has no id
+func EventKey(event Event) string {
+	var id, state, eventTime string
+	switch e := event.(type) {
+	case *MessageEvent:
+		id, state, eventTime = e.MessageID, e.State+e.DeliveryState, e.Time
+	case *IncomingCallEvent:
+		id, state, eventTime = e.CallID, e.CallState, e.Time
+	case *CallTimeoutEvent:
+		id, state, eventTime = e.CallID, e.CallState, e.Time
+	case *MaxDurationEvent:
+		id, state, eventTime = e.CallID, e.CallState, e.Time
+	case *RecordingEvent:
+		id, state, eventTime = e.RecordingID, e.State, e.Time
+	case *UnknownEvent:
+		ids := make([]string, 0, len(eventIDFields))
+		for _, field := range eventIDFields {
+			if value, ok := e.Data[field]; ok && value != nil {
+				ids = append(ids, fmt.Sprint(value))
+			}
+		}
+		id, eventTime = strings.Join(ids, "/"), e.Time
+		if value, ok := e.Data["state"]; ok && value != nil {
+			state = fmt.Sprint(value)
+		}
+	default:
+		return ""
+	}
+	if id == "" {
+		return ""
+	}
+	return strings.Join([]string{event.EventType(), id, state, eventTime}, "|")
+}
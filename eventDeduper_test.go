@@ -0,0 +1,65 @@
+package bandwidth
+
+import (
+	"errors"
+	"testing"
+)
+
+type failingEventStore struct{}
+
+func (s *failingEventStore) MarkProcessed(key string) (bool, error) {
+	return false, errors.New("Store is unavailable")
+}
+
+func TestEventDeduper(t *testing.T) {
+	deduper := NewEventDeduper(nil)
+	event := &MessageEvent{BaseEvent: BaseEvent{Type: "sms", Time: "2012-11-14T16:13:06.076Z"}, MessageID: "m-123", State: "received"}
+	duplicate, err := deduper.IsDuplicate(event)
+	expectNil(t, err)
+	expect(t, duplicate, false)
+	redelivered := *event
+	duplicate, _ = deduper.IsDuplicate(&redelivered)
+	expect(t, duplicate, true)
+	delivered := *event
+	delivered.DeliveryState = "delivered"
+	duplicate, _ = deduper.IsDuplicate(&delivered)
+	expect(t, duplicate, false)
+}
+
+func TestEventDeduperWithoutID(t *testing.T) {
+	deduper := NewEventDeduper(nil)
+	event := &IncomingCallEvent{BaseEvent: BaseEvent{Type: "incomingcall"}}
+	deduper.IsDuplicate(event)
+	duplicate, err := deduper.IsDuplicate(event)
+	expectNil(t, err)
+	expect(t, duplicate, false)
+}
+
+func TestEventDeduperFail(t *testing.T) {
+	deduper := NewEventDeduper(&failingEventStore{})
+	shouldFail(t, func() (interface{}, error) {
+		return deduper.IsDuplicate(&RecordingEvent{BaseEvent: BaseEvent{Type: "recording"}, RecordingID: "r-123"})
+	})
+}
+
+func TestMemoryEventStore(t *testing.T) {
+	store := NewMemoryEventStore(2)
+	store.MarkProcessed("1")
+	store.MarkProcessed("2")
+	seen, _ := store.MarkProcessed("1")
+	expect(t, seen, true)
+	store.MarkProcessed("3") // "2" is least recently used
+	seen, _ = store.MarkProcessed("2")
+	expect(t, seen, false)
+	seen, _ = store.MarkProcessed("3")
+	expect(t, seen, true)
+	expect(t, NewMemoryEventStore(0).capacity, DefaultEventStoreCapacity)
+}
+
+func TestEventKey(t *testing.T) {
+	expect(t, EventKey(&RecordingEvent{BaseEvent: BaseEvent{Type: "recording", Time: "t1"}, RecordingID: "r-123", State: "complete"}), "recording|r-123|complete|t1")
+	expect(t, EventKey(&UnknownEvent{BaseEvent: BaseEvent{Type: "answer", Time: "t1"}, Data: map[string]interface{}{"callId": "c-123", "eventType": "answer"}}), "answer|c-123||t1")
+	expect(t, EventKey(&MaxDurationEvent{BaseEvent: BaseEvent{Type: "maxDuration", Time: "t1"}, CallID: "c-123", CallState: "completed"}), "maxDuration|c-123|completed|t1")
+	expect(t, EventKey(&UnknownEvent{BaseEvent: BaseEvent{Type: "answer"}}), "")
+	expect(t, EventKey(&BaseEvent{Type: "answer"}), "")
+}
@@ -3,14 +3,56 @@ package bandwidth
 import (
 	"fmt"
 	"io"
-	"io/ioutil"
+	"math/rand"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
 )
 
 const mediaPath = "media"
 
+// MaxMMSMediaSize is max size of MMS media file in bytes
+const MaxMMSMediaSize = 3670016 // 3.5 MB
+
+// MMSMediaTypes is list of content types supported by MMS
+var MMSMediaTypes = []string{
+	"image/jpeg", "image/png", "image/gif", "image/bmp",
+	"audio/mpeg", "audio/mp4", "audio/amr", "audio/wav", "audio/3gpp",
+	"video/mp4", "video/3gpp", "video/mpeg", "video/quicktime",
+	"text/plain", "text/vcard", "text/x-vcard", "text/calendar",
+	"application/pdf", "application/smil",
+}
+
+// ValidateMMSMedia checks if media file with given content type and size (in bytes) can be sent via MMS
+// It returns error object
+// example: err := bandwidth.ValidateMMSMedia("image/png", 102400)
+func ValidateMMSMedia(contentType string, size int64) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("Invalid content type %q: %s", contentType, err.Error())
+	}
+	supported := false
+	for _, t := range MMSMediaTypes {
+		if strings.EqualFold(t, mediaType) {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return fmt.Errorf("Content type %s is not supported by MMS", mediaType)
+	}
+	if size <= 0 {
+		return fmt.Errorf("Media file is empty")
+	}
+	if size > MaxMMSMediaSize {
+		return fmt.Errorf("Media file size %d exceeds MMS limit of %d bytes", size, MaxMMSMediaSize)
+	}
+	return nil
+}
+
 // MediaFile struct
 type MediaFile struct {
 	ContentLength int64  `json:"contentLength"`
@@ -21,11 +63,7 @@ type MediaFile struct {
 // GetMediaFiles returns  a list of your media files
 // It returns list of MediaFile instances or error
 func (api *Client) GetMediaFiles() ([]*MediaFile, error) {
-	result, _, err := api.makeRequest(http.MethodGet, api.concatUserPath(mediaPath), &[]*MediaFile{})
-	if err != nil {
-		return nil, err
-	}
-	return *(result.(*[]*MediaFile)), nil
+	return listTyped[MediaFile](api, api.concatUserPath(mediaPath), nil)
 }
 
 // DeleteMediaFile removes a media file
@@ -35,54 +73,128 @@ func (api *Client) DeleteMediaFile(name string) error {
 	return err
 }
 
-// UploadMediaFile creates a new media from file or any io.ReadCloser instance
+// UploadMediaFile creates a new media from file or any io.Reader instance
 // It returns error object
 // example: api.UploadMediaFile("file.jpg", "/path/ti/file.jpg", "image/jpeg")
-// api.UploadMediaFile("file.bin", readCloserInstance) // using io.ReadCloser instance
+// api.UploadMediaFile("file.bin", readerInstance) // using io.Reader instance
 func (api *Client) UploadMediaFile(name string, file interface{}, contentType ...string) error {
-	request, err := api.createRequest(http.MethodPut, fmt.Sprintf("%s/%s", api.concatUserPath(mediaPath), url.QueryEscape(name)), "v1")
-	if err != nil {
-		return err
-	}
+	mediaType := "application/octet-stream"
 	if len(contentType) > 0 {
-		request.Header.Set("Content-Type", contentType[0])
-	} else {
-		request.Header.Set("Content-Type", "application/octet-stream")
+		mediaType = contentType[0]
 	}
-	switch file.(type) {
+	var body io.Reader
+	switch content := file.(type) {
 	case string:
-		request.Body, err = os.Open(file.(string))
+		f, err := os.Open(content)
 		if err != nil {
 			return err
 		}
+		// the transport closes the body after sending, this close is for requests failed before that
+		defer f.Close()
+		body = f
+	case io.Reader:
+		body = content
 	default:
-		request.Body = file.(io.ReadCloser)
+		return fmt.Errorf("Unsupported media content %T (file path or io.Reader is expected)", file)
 	}
-	client := &http.Client{}
-	response, err := client.Do(request)
+	response, err := api.makeRawRequest(http.MethodPut, fmt.Sprintf("%s/%s", api.concatUserPath(mediaPath), url.QueryEscape(name)), api.APIVersion(mediaPath), body, mediaType)
 	if err != nil {
 		return err
 	}
-	_, _, err = api.checkResponse(response, nil)
-	return err
+	drainAndClose(response.Body)
+	return nil
 }
 
 // DownloadMediaFile download media ffile
 // It returns error io.ReadCloser, cotent type of downloaded file or error
 // example: stream, contentType,  err := api.DownloadMediaFile("file.jpg")
 func (api *Client) DownloadMediaFile(name string) (io.ReadCloser, string, error) {
-	request, err := api.createRequest(http.MethodGet, fmt.Sprintf("%s/%s", api.concatUserPath(mediaPath), url.QueryEscape(name)), "v1")
+	response, err := api.downloadMediaFile(name)
 	if err != nil {
 		return nil, "", err
 	}
-	client := &http.Client{}
-	response, err := client.Do(request)
+	return response.Body, response.Header.Get("Content-Type"), nil
+}
+
+func (api *Client) downloadMediaFile(name string) (*http.Response, error) {
+	return api.makeRawRequest(http.MethodGet, fmt.Sprintf("%s/%s", api.concatUserPath(mediaPath), url.QueryEscape(name)), api.APIVersion(mediaPath), nil, "")
+}
+
+// DownloadMediaFileToWriter streams media file to the writer (without buffering whole file in memory)
+// It returns count of written bytes or error (including errors of interrupted or incomplete download)
+// example: size, err := api.DownloadMediaFileToWriter("file.jpg", writer)
+func (api *Client) DownloadMediaFileToWriter(name string, w io.Writer) (int64, error) {
+	response, err := api.downloadMediaFile(name)
 	if err != nil {
-		return nil, "", err
+		return 0, err
 	}
-	if response.StatusCode >= 400 {
-		text, _ := ioutil.ReadAll(response.Body)
-		return nil, "", fmt.Errorf("Http code %d: %s", response.StatusCode, text)
+	return copyMediaFile(name, response, w)
+}
+
+// DownloadMediaFileToPath downloads media file to local file
+// The media is written to a temporary file in the same directory which replaces the file at path only when download completes,
+// so existing file is kept if the API returns an error or download fails.
+// The file gets the same permissions as with os.Create() (permissions of existing file are kept).
+// It returns error object
+// example: err := api.DownloadMediaFileToPath("file.jpg", "/path/to/file.jpg")
+func (api *Client) DownloadMediaFileToPath(name, path string) error {
+	response, err := api.downloadMediaFile(name)
+	if err != nil {
+		return err
 	}
-	return response.Body, response.Header.Get("Content-Type"), nil
+	file, err := createTempFile(path)
+	if err != nil {
+		drainAndClose(response.Body)
+		return err
+	}
+	_, err = copyMediaFile(name, response, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	return nil
+}
+
+// createTempFile creates new temporary file in directory of path with permissions which os.Create() would give to path
+func createTempFile(path string) (*os.File, error) {
+	info, statErr := os.Stat(path)
+	for attempt := 1; ; attempt++ {
+		name := filepath.Join(filepath.Dir(path), fmt.Sprintf(".%s.%d.tmp", filepath.Base(path), rand.Uint32()))
+		// new files get 0666 (before umask) like with os.Create()
+		file, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0666)
+		if os.IsExist(err) && attempt < 100 {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if statErr == nil {
+			// os.Create() keeps permissions of existing file
+			if err := file.Chmod(info.Mode().Perm()); err != nil {
+				file.Close()
+				os.Remove(name)
+				return nil, err
+			}
+		}
+		return file, nil
+	}
+}
+
+// copyMediaFile copies body of download response to the writer and closes it
+func copyMediaFile(name string, response *http.Response, w io.Writer) (int64, error) {
+	defer response.Body.Close()
+	written, err := io.Copy(w, response.Body)
+	if err != nil {
+		return written, err
+	}
+	if response.ContentLength >= 0 && written != response.ContentLength {
+		return written, fmt.Errorf("Media file %s is incomplete: received %d of %d bytes", name, written, response.ContentLength)
+	}
+	return written, nil
 }
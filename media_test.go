@@ -2,9 +2,14 @@ package bandwidth
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"bytes"
+	"errors"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 )
 
 func TestGetMediaFiles(t *testing.T) {
@@ -118,3 +123,179 @@ func TestDownloadMediaFileFail(t *testing.T) {
 		t.Error("Should fail here")
 	}
 }
+
+func TestUploadMediaFileWithReader(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/media/file1",
+		Method:           http.MethodPut,
+		EstimatedContent: "123",
+		EstimatedHeaders: map[string]string{"Content-Type": "text/plain"}}})
+	defer server.Close()
+	expectNil(t, api.UploadMediaFile("file1", strings.NewReader("123"), "text/plain"))
+	shouldFail(t, func() (interface{}, error) { return nil, api.UploadMediaFile("file1", 123) })
+}
+
+func TestDownloadMediaFileFailWithTextBody(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/media/file1",
+		Method:           http.MethodGet,
+		ContentToSend:    "Not found",
+		HeadersToSend:    map[string]string{"Content-Type": "text/plain"},
+		StatusCodeToSend: http.StatusNotFound}})
+	defer server.Close()
+	_, _, err := api.DownloadMediaFile("file1")
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("Should return APIError but got %v", err)
+	}
+	expect(t, apiErr.StatusCode, http.StatusNotFound)
+	expect(t, apiErr.Error(), "Http code 404: Not found")
+}
+
+func TestDownloadMediaFileFailWithJSONBody(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/media/file1",
+		Method:           http.MethodGet,
+		ContentToSend:    `{"code": "media-not-found", "message": "Media not found"}`,
+		StatusCodeToSend: http.StatusNotFound}})
+	defer server.Close()
+	_, _, err := api.DownloadMediaFile("file1")
+	expect(t, err.(*APIError).Code, "media-not-found")
+}
+
+func TestValidateMMSMedia(t *testing.T) {
+	expectNil(t, ValidateMMSMedia("image/jpeg", 1024))
+	expectNil(t, ValidateMMSMedia("Image/PNG", MaxMMSMediaSize))
+	expectNil(t, ValidateMMSMedia("text/plain; charset=utf-8", 10))
+}
+
+func TestValidateMMSMediaFail(t *testing.T) {
+	for _, err := range []error{
+		ValidateMMSMedia("application/zip", 1024),
+		ValidateMMSMedia("", 1024),
+		ValidateMMSMedia("image/jpeg", 0),
+		ValidateMMSMedia("image/jpeg", MaxMMSMediaSize+1),
+	} {
+		if err == nil {
+			t.Error("Should fail here")
+		}
+	}
+}
+
+type failingWriter struct{}
+
+func (w failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("Disk is full")
+}
+
+func TestDownloadMediaFileToWriter(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/media/file1",
+		Method:        http.MethodGet,
+		ContentToSend: "123"}})
+	defer server.Close()
+	var buffer bytes.Buffer
+	written, err := api.DownloadMediaFileToWriter("file1", &buffer)
+	expectNil(t, err)
+	expect(t, written, int64(4))
+	expect(t, buffer.String(), "123\n")
+	shouldFail(t, func() (interface{}, error) { return api.DownloadMediaFileToWriter("file1", failingWriter{}) })
+}
+
+func TestDownloadMediaFileToWriterFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/media/file1",
+		Method:           http.MethodGet,
+		ContentToSend:    "Not found",
+		StatusCodeToSend: http.StatusNotFound}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/media/file2",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusTooManyRequests}})
+	defer server.Close()
+	var buffer bytes.Buffer
+	_, err := api.DownloadMediaFileToWriter("file1", &buffer)
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("Should return APIError but got %v", err)
+	}
+	expect(t, apiErr.StatusCode, http.StatusNotFound)
+	_, err = api.DownloadMediaFileToWriter("file2", &buffer)
+	if _, ok := err.(*RateLimitError); !ok {
+		t.Fatalf("Should return RateLimitError but got %v", err)
+	}
+	expect(t, buffer.Len(), 0)
+}
+
+func TestDownloadMediaFileToWriterWithIncompleteBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "10")
+		w.Write([]byte("123"))
+	}))
+	defer server.Close()
+	api, _ := New("userId", "apiToken", "apiSecret", WithEndpoint(server.URL))
+	var buffer bytes.Buffer
+	written, err := api.DownloadMediaFileToWriter("file1", &buffer)
+	if err == nil {
+		t.Fatal("Should fail here")
+	}
+	expect(t, written, int64(3))
+}
+
+func TestDownloadMediaFileToPath(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/media/file1",
+		Method:        http.MethodGet,
+		ContentToSend: "123"}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/media/file2",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusNotFound}})
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "file1")
+	expectNil(t, api.DownloadMediaFileToPath("file1", path))
+	data, _ := ioutil.ReadFile(path)
+	expect(t, string(data), "123\n")
+	missingPath := filepath.Join(t.TempDir(), "file2")
+	shouldFail(t, func() (interface{}, error) { return nil, api.DownloadMediaFileToPath("file2", missingPath) })
+	if _, err := os.Stat(missingPath); !os.IsNotExist(err) {
+		t.Error("File should not be created")
+	}
+}
+
+func TestDownloadMediaFileToPathWithPermissions(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/media/file1",
+		Method:        http.MethodGet,
+		ContentToSend: "123"}})
+	defer server.Close()
+	dir := t.TempDir()
+	created, err := os.Create(filepath.Join(dir, "created"))
+	expectNil(t, err)
+	created.Close()
+	createdInfo, _ := os.Stat(created.Name())
+	path := filepath.Join(dir, "file1")
+	expectNil(t, api.DownloadMediaFileToPath("file1", path))
+	info, err := os.Stat(path)
+	expectNil(t, err)
+	expect(t, info.Mode().Perm(), createdInfo.Mode().Perm())
+	expectNil(t, os.Chmod(path, 0640))
+	expectNil(t, api.DownloadMediaFileToPath("file1", path))
+	info, _ = os.Stat(path)
+	expect(t, info.Mode().Perm(), os.FileMode(0640))
+}
+
+func TestDownloadMediaFileToPathWithIncompleteBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "10")
+		w.Write([]byte("123"))
+	}))
+	defer server.Close()
+	api, _ := New("userId", "apiToken", "apiSecret", WithEndpoint(server.URL))
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file1")
+	expectNil(t, ioutil.WriteFile(path, []byte("previous"), 0644))
+	shouldFail(t, func() (interface{}, error) { return nil, api.DownloadMediaFileToPath("file1", path) })
+	data, _ := ioutil.ReadFile(path)
+	expect(t, string(data), "previous")
+	files, _ := ioutil.ReadDir(dir)
+	expect(t, len(files), 1) // temporary file is removed
+}
@@ -0,0 +1,90 @@
+package bandwidth
+
+import (
+	"errors"
+	"strings"
+)
+
+const gsm7BasicChars = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// these characters take 2 septets in GSM-7 encoding
+const gsm7ExtensionChars = "^{}\\[~]|€\f"
+
+// EstimateMessageSegments returns count of SMS segments required to send the text
+// GSM-7 text takes 160 characters in single segment (153 per segment for long messages), other text (UCS-2) 70 (67 per segment)
+// example: bandwidth.EstimateMessageSegments("Hello") // 1
+func EstimateMessageSegments(text string) int {
+	if text == "" {
+		return 1
+	}
+	septets := 0
+	gsm7 := true
+	for _, c := range text {
+		if strings.ContainsRune(gsm7BasicChars, c) {
+			septets++
+		} else if strings.ContainsRune(gsm7ExtensionChars, c) {
+			septets += 2
+		} else {
+			gsm7 = false
+			break
+		}
+	}
+	if gsm7 {
+		return segmentsCount(septets, 160, 153)
+	}
+	units := 0
+	for _, c := range text {
+		// characters outside of BMP take 2 UTF-16 code units
+		if c > 0xFFFF {
+			units += 2
+		} else {
+			units++
+		}
+	}
+	return segmentsCount(units, 70, 67)
+}
+
+func segmentsCount(length, single, multi int) int {
+	if length <= single {
+		return 1
+	}
+	return (length + multi - 1) / multi
+}
+
+// WithMessageRates sets per-unit rates used by EstimateMessageCost(): price of one SMS segment and one MMS message
+// example: api := bandwidth.New("userId", "apiToken", "apiSecret", bandwidth.WithMessageRates(0.0075, 0.015))
+func WithMessageRates(smsSegmentRate, mmsRate float64) Option {
+	return func(c *Client) error {
+		if smsSegmentRate < 0 || mmsRate < 0 {
+			return errors.New("Message rates can't be negative")
+		}
+		c.SMSSegmentRate = smsSegmentRate
+		c.MMSRate = mmsRate
+		return nil
+	}
+}
+
+// EstimateMessageCost returns estimated cost of sending of SMS with given count of segments (or given count of MMS if mms is true)
+// Rates are account-specific, set them by WithMessageRates() option or Client.SMSSegmentRate/Client.MMSRate.
+// It returns estimated cost or error if the rate is not configured
+// example: cost, err := api.EstimateMessageCost(bandwidth.EstimateMessageSegments(text), false)
+func (api *Client) EstimateMessageCost(segments int, mms bool) (float64, error) {
+	if segments < 0 {
+		return 0, errors.New("Count of segments can't be negative")
+	}
+	rate := api.SMSSegmentRate
+	if mms {
+		rate = api.MMSRate
+	}
+	if rate == 0 {
+		return 0, errors.New("Message rates are not configured. Please use bandwidth.WithMessageRates() option")
+	}
+	return float64(segments) * rate, nil
+}
+
+// EstimateTextMessageCost returns estimated cost of sending of the text via SMS
+// It returns estimated cost or error if the rate is not configured
+func (api *Client) EstimateTextMessageCost(text string) (float64, error) {
+	return api.EstimateMessageCost(EstimateMessageSegments(text), false)
+}
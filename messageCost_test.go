@@ -0,0 +1,45 @@
+package bandwidth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEstimateMessageSegments(t *testing.T) {
+	expect(t, EstimateMessageSegments(""), 1)
+	expect(t, EstimateMessageSegments("Hello"), 1)
+	expect(t, EstimateMessageSegments(strings.Repeat("a", 160)), 1)
+	expect(t, EstimateMessageSegments(strings.Repeat("a", 161)), 2)
+	expect(t, EstimateMessageSegments(strings.Repeat("a", 306)), 2)
+	expect(t, EstimateMessageSegments(strings.Repeat("a", 307)), 3)
+	expect(t, EstimateMessageSegments(strings.Repeat("€", 80)), 1)
+	expect(t, EstimateMessageSegments(strings.Repeat("€", 81)), 2)
+	expect(t, EstimateMessageSegments(strings.Repeat("ж", 70)), 1)
+	expect(t, EstimateMessageSegments(strings.Repeat("ж", 71)), 2)
+	expect(t, EstimateMessageSegments(strings.Repeat("😀", 35)), 1)
+	expect(t, EstimateMessageSegments(strings.Repeat("😀", 36)), 2)
+}
+
+func TestEstimateMessageCost(t *testing.T) {
+	api, _ := New("userId", "apiToken", "apiSecret", WithMessageRates(0.5, 2))
+	cost, err := api.EstimateMessageCost(3, false)
+	expectNil(t, err)
+	expect(t, cost, 1.5)
+	cost, err = api.EstimateMessageCost(1, true)
+	expectNil(t, err)
+	expect(t, cost, 2.0)
+	cost, err = api.EstimateTextMessageCost(strings.Repeat("a", 161))
+	expectNil(t, err)
+	expect(t, cost, 1.0)
+}
+
+func TestEstimateMessageCostFail(t *testing.T) {
+	api := getAPI()
+	shouldFail(t, func() (interface{}, error) { return api.EstimateMessageCost(1, false) })
+	shouldFail(t, func() (interface{}, error) { return api.EstimateMessageCost(1, true) })
+	api.SMSSegmentRate = 1
+	shouldFail(t, func() (interface{}, error) { return api.EstimateMessageCost(-1, false) })
+	shouldFail(t, func() (interface{}, error) {
+		return New("userId", "apiToken", "apiSecret", WithMessageRates(-1, 1))
+	})
+}
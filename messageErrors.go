@@ -0,0 +1,73 @@
+package bandwidth
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Categories of message delivery failures returned by FailureCategory()
+const (
+	FailureCategoryNone               = ""
+	FailureCategoryMalformed          = "malformed"
+	FailureCategoryInvalidNumber      = "invalid-number"
+	FailureCategorySpamBlocked        = "spam-blocked"
+	FailureCategoryOptedOut           = "opted-out"
+	FailureCategoryExpired            = "expired"
+	FailureCategoryCarrierRejected    = "carrier-rejected"
+	FailureCategoryCarrierUnavailable = "carrier-unavailable"
+	FailureCategoryUnknown            = "unknown"
+)
+
+var messageFailureCategories = map[int]string{
+	4301: FailureCategoryMalformed,
+	4350: FailureCategoryMalformed,
+	4360: FailureCategoryExpired,
+	4404: FailureCategoryInvalidNumber,
+	4405: FailureCategoryInvalidNumber,
+	4406: FailureCategoryInvalidNumber,
+	4420: FailureCategoryInvalidNumber,
+	4421: FailureCategoryInvalidNumber,
+	4432: FailureCategoryInvalidNumber,
+	4434: FailureCategoryInvalidNumber,
+	4470: FailureCategorySpamBlocked,
+	4481: FailureCategorySpamBlocked,
+	4492: FailureCategoryExpired,
+	4720: FailureCategoryInvalidNumber,
+	4730: FailureCategoryInvalidNumber,
+	4740: FailureCategoryInvalidNumber,
+	4770: FailureCategorySpamBlocked,
+	4775: FailureCategoryOptedOut,
+	5600: FailureCategoryCarrierUnavailable,
+	5610: FailureCategoryCarrierUnavailable,
+	5620: FailureCategoryCarrierUnavailable,
+	5650: FailureCategoryCarrierRejected,
+	9902: FailureCategoryExpired,
+}
+
+// parseDeliveryCode returns error code from deliveryCode field of message (zero for empty or non-numeric codes)
+func parseDeliveryCode(code string) int {
+	value, err := strconv.Atoi(strings.TrimSpace(code))
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// FailureCategory maps Bandwidth's message error code to a failure category (one of FailureCategory* constants)
+// Zero code means no failure. Unknown 47xx codes are treated as carrier rejects, unknown 5xxx codes as carrier failures.
+// example: bandwidth.FailureCategory(4770) // "spam-blocked"
+func FailureCategory(code int) string {
+	if code == 0 {
+		return FailureCategoryNone
+	}
+	if category, ok := messageFailureCategories[code]; ok {
+		return category
+	}
+	switch {
+	case code >= 4700 && code < 4800:
+		return FailureCategoryCarrierRejected
+	case code >= 5000 && code < 6000:
+		return FailureCategoryCarrierUnavailable
+	}
+	return FailureCategoryUnknown
+}
@@ -0,0 +1,41 @@
+package bandwidth
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestFailureCategory(t *testing.T) {
+	expect(t, FailureCategory(0), FailureCategoryNone)
+	expect(t, FailureCategory(4301), FailureCategoryMalformed)
+	expect(t, FailureCategory(4720), FailureCategoryInvalidNumber)
+	expect(t, FailureCategory(4770), FailureCategorySpamBlocked)
+	expect(t, FailureCategory(4775), FailureCategoryOptedOut)
+	expect(t, FailureCategory(4751), FailureCategoryCarrierRejected)
+	expect(t, FailureCategory(5650), FailureCategoryCarrierRejected)
+	expect(t, FailureCategory(5999), FailureCategoryCarrierUnavailable)
+	expect(t, FailureCategory(9902), FailureCategoryExpired)
+	expect(t, FailureCategory(1234), FailureCategoryUnknown)
+}
+
+func TestMessageFailureCategory(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: "/v1/users/userId/messages/123",
+		Method:       http.MethodGet,
+		ContentToSend: `{
+			"id": "123",
+			"state": "error",
+			"deliveryState": "not-delivered",
+			"deliveryCode": "4720"
+		}`}})
+	defer server.Close()
+	result, err := api.GetMessage("123")
+	if err != nil {
+		t.Error("Failed call of GetMessage()")
+		return
+	}
+	expect(t, result.ErrorCode(), 4720)
+	expect(t, result.FailureCategory(), FailureCategoryInvalidNumber)
+	expect(t, (&Message{DeliveryCode: "0"}).FailureCategory(), FailureCategoryNone)
+	expect(t, (&Message{DeliveryCode: "n/a"}).ErrorCode(), 0)
+}
@@ -0,0 +1,114 @@
+package bandwidth
+
+import "context"
+
+// MessageIterator iterates over all messages page by page (following next page links of the API)
+// A single iterator is not safe for concurrent use, but many iterators created from one Client
+// can be used in parallel (the client keeps no pagination state).
+// Create the iterator from api.WithContext(ctx) to make its requests with the context.
+// example: iterator := api.NewMessageIterator(&bandwidth.GetMessagesQuery{Direction: "in"})
+// for iterator.Next() { fmt.Println(iterator.Message().Text) }
+// if err := iterator.Err(); err != nil { ... }
+type MessageIterator struct {
+	api     *Client
+	query   interface{}
+	visited map[string]bool
+	page    []*Message
+	index   int
+	current *Message
+	done    bool
+	err     error
+}
+
+// NewMessageIterator creates iterator for messages matched to the query
+// Page field of the query is used as first page, Size (if set) as page size.
+func (api *Client) NewMessageIterator(query ...*GetMessagesQuery) *MessageIterator {
+	options := &GetMessagesQuery{}
+	if len(query) > 0 && query[0] != nil {
+		copied := *query[0]
+		options = &copied
+	}
+	if options.Size <= 0 {
+		options.Size = maxPageSize
+	}
+	return &MessageIterator{api: api, query: options, visited: map[string]bool{encodeQuery(options).Encode(): true}, index: -1}
+}
+
+// Next moves to next message (requesting next page if need)
+// It returns false when there are no more messages or an error occurred
+func (i *MessageIterator) Next() bool {
+	if i.err != nil {
+		return false
+	}
+	for i.index+1 >= len(i.page) {
+		if i.done {
+			i.current = nil
+			return false
+		}
+		result, pagination, err := i.api.getListPage(i.api.concatUserPath(messagesPath), &[]*Message{}, i.query)
+		var nextQuery map[string]string
+		if err == nil {
+			nextQuery, err = nextPageQuery(pagination, i.visited)
+		}
+		if err != nil {
+			i.err = err
+			i.current = nil
+			return false
+		}
+		i.page = *(result.(*[]*Message))
+		i.index = -1
+		i.query, i.done = nextQuery, nextQuery == nil
+	}
+	i.index++
+	i.current = i.page[i.index]
+	return true
+}
+
+// Message returns current message
+func (i *MessageIterator) Message() *Message {
+	return i.current
+}
+
+// Err returns error occurred on requesting of messages
+func (i *MessageIterator) Err() error {
+	return i.err
+}
+
+// StreamMessages sends all messages matched to the query to returned channel (requesting pages while the channel is read)
+// Requests are made with ctx, so a page request in progress is aborted when ctx is done.
+// Both channels are closed when all messages are sent, on error (sent to error channel) or when ctx is done (ctx.Err() is sent to error channel).
+// example: messages, errs := api.StreamMessages(ctx, &bandwidth.GetMessagesQuery{Direction: "in"})
+// for message := range messages { ... }
+// if err := <-errs; err != nil { ... }
+func (api *Client) StreamMessages(ctx context.Context, query *GetMessagesQuery) (<-chan *Message, <-chan error) {
+	messages := make(chan *Message)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(messages)
+		iterator := api.WithContext(ctx).NewMessageIterator(query)
+		for {
+			if err := ctx.Err(); err != nil {
+				errs <- err
+				return
+			}
+			if !iterator.Next() {
+				break
+			}
+			select {
+			case messages <- iterator.Message():
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+		if err := iterator.Err(); err != nil {
+			if ctx.Err() != nil {
+				// the request was aborted because ctx is done
+				err = ctx.Err()
+			}
+			errs <- err
+		}
+	}()
+	return messages, errs
+}
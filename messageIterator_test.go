@@ -0,0 +1,143 @@
+package bandwidth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func getMessageIteratorHandlers() []RequestHandler {
+	return []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/messages?size=2",
+		Method:        http.MethodGet,
+		HeadersToSend: map[string]string{"Link": `<https://api.catapult.inetwork.com/v1/users/userId/messages?page=1&size=2>; rel="next"`},
+		ContentToSend: `[{"id": "1"}, {"id": "2"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/messages?page=1&size=2",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "3"}]`}}
+}
+
+func readMessageIDs(iterator *MessageIterator) []string {
+	ids := []string{}
+	for iterator.Next() {
+		ids = append(ids, iterator.Message().ID)
+	}
+	return ids
+}
+
+func TestMessageIterator(t *testing.T) {
+	server, api := startMockServer(t, getMessageIteratorHandlers())
+	defer server.Close()
+	iterator := api.NewMessageIterator(&GetMessagesQuery{Size: 2})
+	expect(t, readMessageIDs(iterator), []string{"1", "2", "3"})
+	expectNil(t, iterator.Err())
+	expect(t, iterator.Next(), false)
+}
+
+func TestMessageIteratorWithFullLastPage(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/messages?size=2",
+		Method:        http.MethodGet,
+		HeadersToSend: map[string]string{"Link": `<https://api.catapult.inetwork.com/v1/users/userId/messages?page=1&size=2>; rel="next"`},
+		ContentToSend: `[{"id": "1"}, {"id": "2"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/messages?page=1&size=2",
+		Method:        http.MethodGet,
+		HeadersToSend: map[string]string{"Link": `<https://api.catapult.inetwork.com/v1/users/userId/messages?page=1&size=2>; rel="next"`},
+		ContentToSend: `[]`}})
+	defer server.Close()
+	iterator := api.NewMessageIterator(&GetMessagesQuery{Size: 2})
+	expect(t, readMessageIDs(iterator), []string{"1", "2"})
+	expectNil(t, iterator.Err())
+}
+
+func TestMessageIteratorConcurrently(t *testing.T) {
+	server, api := startMockServer(t, getMessageIteratorHandlers())
+	defer server.Close()
+	var wg sync.WaitGroup
+	results := make([][]string, 2)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = readMessageIDs(api.NewMessageIterator(&GetMessagesQuery{Size: 2}))
+		}(i)
+	}
+	wg.Wait()
+	expect(t, results[0], []string{"1", "2", "3"})
+	expect(t, results[1], []string{"1", "2", "3"})
+}
+
+func TestMessageIteratorFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/messages?size=1000",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusBadRequest}})
+	defer server.Close()
+	iterator := api.NewMessageIterator()
+	expect(t, iterator.Next(), false)
+	if iterator.Err() == nil {
+		t.Error("Should fail here")
+	}
+}
+
+func TestStreamMessages(t *testing.T) {
+	server, api := startMockServer(t, getMessageIteratorHandlers())
+	defer server.Close()
+	messages, errs := api.StreamMessages(context.Background(), &GetMessagesQuery{Size: 2})
+	ids := []string{}
+	for message := range messages {
+		ids = append(ids, message.ID)
+	}
+	expect(t, ids, []string{"1", "2", "3"})
+	expectNil(t, <-errs)
+}
+
+func TestStreamMessagesWithCancel(t *testing.T) {
+	server, api := startMockServer(t, getMessageIteratorHandlers())
+	defer server.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	messages, errs := api.StreamMessages(ctx, &GetMessagesQuery{Size: 2})
+	message := <-messages
+	expect(t, message.ID, "1")
+	cancel()
+	for range messages {
+	}
+	expect(t, <-errs, context.Canceled)
+}
+
+func TestStreamMessagesWithCancelDuringRequest(t *testing.T) {
+	requested := make(chan bool)
+	release := make(chan bool)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(requested)
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+	api, _ := New("userId", "apiToken", "apiSecret", WithEndpoint(server.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+	messages, errs := api.StreamMessages(ctx, nil)
+	<-requested
+	cancel()
+	for range messages {
+		t.Error("Should not receive messages")
+	}
+	expect(t, <-errs, context.Canceled)
+}
+
+func TestStreamMessagesFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/messages?size=1000",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusBadRequest}})
+	defer server.Close()
+	messages, errs := api.StreamMessages(context.Background(), nil)
+	for range messages {
+		t.Error("Should not receive messages")
+	}
+	if <-errs == nil {
+		t.Error("Should fail here")
+	}
+}
@@ -28,6 +28,16 @@ type Message struct {
 	Tag                 string   `json:"tag"`
 }
 
+// ErrorCode returns numeric error code of failed message from its DeliveryCode (zero if there is no failure code)
+func (m *Message) ErrorCode() int {
+	return parseDeliveryCode(m.DeliveryCode)
+}
+
+// FailureCategory returns category of message's failure (see FailureCategory())
+func (m *Message) FailureCategory() string {
+	return FailureCategory(m.ErrorCode())
+}
+
 // CreateMessageData struct
 type CreateMessageData struct {
 	From               string   `json:"from,omitempty"`
@@ -54,6 +64,8 @@ type GetMessagesQuery struct {
 	State         string
 	DeliveryState string
 	SortOrder     string
+	// QueryParams are raw query parameters (see QueryParams type)
+	QueryParams QueryParams
 }
 
 // CreateMessageResult stores status of sent message (in batch mode)
@@ -66,30 +78,66 @@ type CreateMessageResult struct {
 // GetMessages returns list of all messages
 // It returns list of Message instances or error
 func (api *Client) GetMessages(query ...*GetMessagesQuery) ([]*Message, error) {
+	list, _, err := api.GetMessagesPage(query...)
+	return list, err
+}
+
+// GetMessagesPage returns a page of messages like GetMessages() with pagination data of the page
+// It returns list of Message instances, Pagination instance or error
+// example: list, pagination, err := api.GetMessagesPage(&bandwidth.GetMessagesQuery{Page: 1})
+func (api *Client) GetMessagesPage(query ...*GetMessagesQuery) ([]*Message, *Pagination, error) {
 	var options *GetMessagesQuery
 	if len(query) > 0 {
 		options = query[0]
 	}
-	result, _, err := api.makeRequest(http.MethodGet, api.concatUserPath(messagesPath), &[]*Message{}, options)
+	result, pagination, err := api.getListPage(api.concatUserPath(messagesPath), &[]*Message{}, options)
 	if err != nil {
+		return nil, nil, err
+	}
+	return *(result.(*[]*Message)), pagination, nil
+}
+
+// GetAllMessages returns all messages matched to the query (requesting pages one by one while the API returns next page links)
+// It returns list of Message instances or error
+// example: messages, err := api.GetAllMessages(&bandwidth.GetMessagesQuery{Direction: "in", Size: 1000})
+func (api *Client) GetAllMessages(query ...*GetMessagesQuery) ([]*Message, error) {
+	var options *GetMessagesQuery
+	if len(query) > 0 {
+		options = query[0]
+	}
+	list := []*Message{}
+	if err := api.getAllPages(api.concatUserPath(messagesPath), options, &list); err != nil {
 		return nil, err
 	}
-	return *(result.(*[]*Message)), nil
+	return list, nil
 }
 
 // CreateMessage sends a message (SMS/MMS)
 // It returns ID of created message or error
 func (api *Client) CreateMessage(data *CreateMessageData) (string, error) {
-	_, headers, err := api.makeRequest(http.MethodPost, api.concatUserPath(messagesPath), nil, data)
+	if data != nil {
+		if err := api.checkAllowedNumbers(data.To); err != nil {
+			return "", err
+		}
+	}
+	result, headers, err := api.makeRequest(http.MethodPost, api.concatUserPath(messagesPath), nil, data)
 	if err != nil {
 		return "", err
 	}
-	return getIDFromLocationHeader(headers), nil
+	return getCreatedID(result, headers)
 }
 
 // CreateMessages sends some messages (SMS/MMS)
 // It statuses of created messages or error
 func (api *Client) CreateMessages(data ...*CreateMessageData) ([]*CreateMessageResult, error) {
+	for _, item := range data {
+		if item == nil {
+			continue
+		}
+		if err := api.checkAllowedNumbers(item.To); err != nil {
+			return nil, err
+		}
+	}
 	result, _, err := api.makeRequest(http.MethodPost, api.concatUserPath(messagesPath), &[]*CreateMessageResult{}, data)
 	if err != nil {
 		return nil, err
@@ -104,9 +152,5 @@ func (api *Client) CreateMessages(data ...*CreateMessageData) ([]*CreateMessageR
 // GetMessage returns a single message
 // It returns Message instance or error
 func (api *Client) GetMessage(id string) (*Message, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s", api.concatUserPath(messagesPath), id), &Message{})
-	if err != nil {
-		return nil, err
-	}
-	return result.(*Message), nil
+	return getTyped[Message](api, fmt.Sprintf("%s/%s", api.concatUserPath(messagesPath), id), nil)
 }
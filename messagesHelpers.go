@@ -0,0 +1,144 @@
+package bandwidth
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// IdempotencyStore stores IDs of created resources by idempotency key
+// Implement it to share the state between processes (e.g. using Redis)
+type IdempotencyStore interface {
+	// Get returns stored value for the key and true or empty string and false if the key is unknown
+	Get(key string) (string, bool, error)
+	// Set stores value for the key
+	Set(key, value string) error
+}
+
+// MemoryIdempotencyStore is in-memory implementation of IdempotencyStore
+type MemoryIdempotencyStore struct {
+	mutex  sync.RWMutex
+	values map[string]string
+}
+
+// NewMemoryIdempotencyStore creates new in-memory idempotency store
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{values: map[string]string{}}
+}
+
+// Get returns stored value for the key
+func (s *MemoryIdempotencyStore) Get(key string) (string, bool, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	value, ok := s.values[key]
+	return value, ok, nil
+}
+
+// Set stores value for the key
+func (s *MemoryIdempotencyStore) Set(key, value string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.values[key] = value
+	return nil
+}
+
+type idempotencyKeyLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lockIdempotencyKey serializes calls with the same key inside this process
+// It returns function which releases the lock
+func (api *Client) lockIdempotencyKey(key string) func() {
+	state := api.state()
+	state.idempotencyMutex.Lock()
+	if state.idempotencyKeys == nil {
+		state.idempotencyKeys = map[string]*idempotencyKeyLock{}
+	}
+	lock := state.idempotencyKeys[key]
+	if lock == nil {
+		lock = &idempotencyKeyLock{}
+		state.idempotencyKeys[key] = lock
+	}
+	lock.refs++
+	state.idempotencyMutex.Unlock()
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		state.idempotencyMutex.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(state.idempotencyKeys, key)
+		}
+		state.idempotencyMutex.Unlock()
+	}
+}
+
+// idempotencyStore returns IdempotencyStore of the client or its default in-memory store
+// The default store is shared with copies of the client made by WithContext().
+func (api *Client) idempotencyStore() IdempotencyStore {
+	if api.IdempotencyStore != nil {
+		return api.IdempotencyStore
+	}
+	state := api.state()
+	state.idempotencyMutex.Lock()
+	defer state.idempotencyMutex.Unlock()
+	if state.idempotencyStore == nil {
+		state.idempotencyStore = NewMemoryIdempotencyStore()
+	}
+	return state.idempotencyStore
+}
+
+// CreateMessageOnce sends a message (SMS/MMS) only once for given key
+// Repeated (or concurrent) calls with the same key return ID of the message created by the first successful call instead of sending a duplicate.
+// Failed sends are not stored, so they can be retried with the same key.
+// It returns ID of created message or error
+// example: id, err := api.CreateMessageOnce("order-123-shipped", &bandwidth.CreateMessageData{From: "+19195551212", To: "+191955512142", Text:"Shipped"})
+func (api *Client) CreateMessageOnce(key string, data *CreateMessageData) (string, error) {
+	unlock := api.lockIdempotencyKey(key)
+	defer unlock()
+	store := api.idempotencyStore()
+	id, ok, err := store.Get(key)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return id, nil
+	}
+	id, err = api.CreateMessage(data)
+	if err != nil {
+		return "", err
+	}
+	return id, store.Set(key, id)
+}
+
+// MessagingLimits contains messaging rate limits of the account
+type MessagingLimits struct {
+	// Limit is max count of requests per rate limit window
+	Limit int
+	// Remaining is count of requests left in current window
+	Remaining int
+	// Reset is time when current window ends
+	Reset time.Time
+}
+
+// GetMessagingLimits returns current messaging rate limits of the account
+// The API has no endpoint with messaging limits (MPS, daily caps), so they are taken from X-RateLimit-* headers.
+// Headers of last response seen by the client are used (see LastRateLimit()) while their window lasts.
+// Only if there are no such headers yet (or the window has ended) a lightweight messages list request is made
+// (the request is counted by the limits too).
+// It returns MessagingLimits instance or error
+func (api *Client) GetMessagingLimits() (*MessagingLimits, error) {
+	limit := api.LastRateLimit()
+	if limit == nil || limit.Limit == 0 || !limit.Reset.After(timeNow()) {
+		if _, _, err := api.makeRequest(http.MethodGet, api.concatUserPath(messagesPath), &[]*Message{}, &GetMessagesQuery{Size: 1}); err != nil {
+			return nil, err
+		}
+		limit = api.LastRateLimit()
+	}
+	if limit == nil || limit.Limit == 0 {
+		return nil, errors.New("Messaging limits are not available (missing X-RateLimit-* headers)")
+	}
+	return &MessagingLimits{Limit: limit.Limit, Remaining: limit.Remaining, Reset: limit.Reset}, nil
+}
@@ -0,0 +1,159 @@
+package bandwidth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCreateMessageOnce(t *testing.T) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		expect(t, r.URL.String(), "/v1/users/userId/messages")
+		n := atomic.AddInt32(&count, 1)
+		w.Header().Set("Location", "/v1/users/userId/messages/"+strconv.Itoa(int(n)))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	api := getAPI()
+	api.APIEndPoint = server.URL
+	data := &CreateMessageData{From: "fromNumber", To: "toNumber", Text: "text"}
+	var wg sync.WaitGroup
+	ids := make([]string, 5)
+	for i := range ids {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id, err := api.CreateMessageOnce("key", data)
+			if err != nil {
+				t.Error("Failed call of CreateMessageOnce()")
+			}
+			ids[i] = id
+		}(i)
+	}
+	wg.Wait()
+	expect(t, atomic.LoadInt32(&count), int32(1))
+	for _, id := range ids {
+		expect(t, id, "1")
+	}
+	id, _ := api.CreateMessageOnce("other", data)
+	expect(t, id, "2")
+	expect(t, len(api.state().idempotencyKeys), 0)
+}
+
+func TestCreateMessageOnceFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/messages",
+		Method:           http.MethodPost,
+		StatusCodeToSend: http.StatusBadRequest}})
+	defer server.Close()
+	shouldFail(t, func() (interface{}, error) {
+		return api.CreateMessageOnce("key", &CreateMessageData{From: "fromNumber", To: "toNumber", Text: "text"})
+	})
+	_, ok, _ := api.idempotencyStore().Get("key")
+	expect(t, ok, false)
+	expect(t, api.IdempotencyStore, IdempotencyStore(nil))
+}
+
+func TestCreateMessageOnceWithContext(t *testing.T) {
+	var count int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/v1/users/userId/messages/m"+strconv.Itoa(int(atomic.AddInt32(&count, 1))))
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	api, _ := New("userId", "apiToken", "apiSecret", WithEndpoint(server.URL))
+	data := &CreateMessageData{From: "fromNumber", To: "toNumber", Text: "text"}
+	id1, err := api.WithContext(context.Background()).CreateMessageOnce("key", data)
+	expectNil(t, err)
+	id2, err := api.WithContext(context.Background()).CreateMessageOnce("key", data)
+	expectNil(t, err)
+	expect(t, id1, "m1")
+	expect(t, id2, "m1")
+	expect(t, atomic.LoadInt32(&count), int32(1))
+}
+
+type failingIdempotencyStore struct{}
+
+func (failingIdempotencyStore) Get(key string) (string, bool, error) {
+	return "", false, errors.New("store is unavailable")
+}
+
+func (failingIdempotencyStore) Set(key, value string) error {
+	return errors.New("store is unavailable")
+}
+
+func TestCreateMessageOnceWithStoreFail(t *testing.T) {
+	api := getAPI()
+	api.IdempotencyStore = failingIdempotencyStore{}
+	err := shouldFail(t, func() (interface{}, error) {
+		return api.CreateMessageOnce("key", &CreateMessageData{From: "fromNumber", To: "toNumber", Text: "text"})
+	})
+	expect(t, err.Error(), "store is unavailable")
+}
+
+func TestGetMessagingLimits(t *testing.T) {
+	timeNow = func() time.Time { return time.Date(2016, 11, 16, 15, 3, 0, 0, time.UTC) }
+	defer func() { timeNow = time.Now }()
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: "/v1/users/userId/messages?size=1",
+		Method:       http.MethodGet,
+		HeadersToSend: map[string]string{
+			"Date":                  "Wed, 16 Nov 2016 15:03:00 GMT",
+			"X-RateLimit-Limit":     "100",
+			"X-RateLimit-Remaining": "42",
+			"X-RateLimit-Reset":     "1479308598680"},
+		ContentToSend: `[]`}})
+	defer server.Close()
+	limits, err := api.GetMessagingLimits()
+	if err != nil {
+		t.Error("Failed call of GetMessagingLimits()")
+		return
+	}
+	expect(t, limits.Limit, 100)
+	expect(t, limits.Remaining, 42)
+	expect(t, limits.Reset.Unix(), int64(1479308599))
+	server.Close()
+	// headers of last response are used while their window lasts
+	limits, err = api.GetMessagingLimits()
+	expectNil(t, err)
+	expect(t, limits.Remaining, 42)
+}
+
+func TestGetMessagingLimitsWithExpiredWindow(t *testing.T) {
+	now := time.Date(2016, 11, 16, 15, 3, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+	remaining := 42
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", now.Format(http.TimeFormat))
+		w.Header().Set("X-RateLimit-Limit", "100")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(time.Minute).Unix()*1000, 10))
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	api, _ := New("userId", "apiToken", "apiSecret", WithEndpoint(server.URL))
+	limits, _ := api.GetMessagingLimits()
+	expect(t, limits.Remaining, 42)
+	remaining = 100
+	now = now.Add(2 * time.Minute)
+	limits, err := api.GetMessagingLimits()
+	expectNil(t, err)
+	expect(t, limits.Remaining, 100)
+}
+
+func TestGetMessagingLimitsFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/messages?size=1",
+		Method:        http.MethodGet,
+		ContentToSend: `[]`}})
+	defer server.Close()
+	shouldFail(t, func() (interface{}, error) { return api.GetMessagingLimits() })
+}
@@ -1,6 +1,8 @@
 package bandwidth
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 )
@@ -31,9 +33,80 @@ type CreateMessageResultV2 struct {
 
 // CreateMessageV2 sends a message (SMS/MMS)
 func (api *Client) CreateMessageV2(data *CreateMessageDataV2) (*CreateMessageResultV2, error) {
+	if data != nil {
+		if err := api.checkAllowedMessageV2Recipients(data.To); err != nil {
+			return nil, err
+		}
+	}
 	result, _, err := api.makeRequestV2(http.MethodPost, api.concatUserPath(messagesPath), &CreateMessageResultV2{}, data)
 	if err != nil {
 		return nil, err
 	}
 	return result.(*CreateMessageResultV2), nil
 }
+
+// CreateMessagesResultV2 is result of one message sent by CreateMessagesV2()
+// Error is set for messages which were not sent (other fields are empty then)
+type CreateMessagesResultV2 struct {
+	CreateMessageResultV2
+	Error *MessageErrorV2 `json:"error,omitempty"`
+}
+
+// MessageErrorV2 is error of one message of CreateMessagesV2()
+type MessageErrorV2 struct {
+	// Code is "code" field of the error (numeric codes are converted to strings)
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *MessageErrorV2) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return e.Code
+}
+
+// UnmarshalJSON decodes the error (code can be number or string)
+func (e *MessageErrorV2) UnmarshalJSON(data []byte) error {
+	type rawMessageError MessageErrorV2
+	raw := struct {
+		*rawMessageError
+		Code json.RawMessage `json:"code"`
+	}{rawMessageError: (*rawMessageError)(e)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	e.Code = ""
+	if len(raw.Code) == 0 || string(raw.Code) == "null" {
+		return nil
+	}
+	if raw.Code[0] == '"' {
+		return json.Unmarshal(raw.Code, &e.Code)
+	}
+	var code json.Number
+	if err := json.Unmarshal(raw.Code, &code); err != nil {
+		return fmt.Errorf("Invalid message error code %s", raw.Code)
+	}
+	e.Code = code.String()
+	return nil
+}
+
+// CreateMessagesV2 sends some messages (SMS/MMS) by one request
+// Failed messages don't fail the call, check Error of their results.
+// It returns results in order of messages or error
+// example: results, err := api.CreateMessagesV2(&bandwidth.CreateMessageDataV2{From: "+19195551212", To: "+19195551213", Text: "Hello"})
+func (api *Client) CreateMessagesV2(data ...*CreateMessageDataV2) ([]*CreateMessagesResultV2, error) {
+	for _, item := range data {
+		if item == nil {
+			continue
+		}
+		if err := api.checkAllowedMessageV2Recipients(item.To); err != nil {
+			return nil, err
+		}
+	}
+	result, _, err := api.makeRequestV2(http.MethodPost, api.concatUserPath(messagesPath), &[]*CreateMessagesResultV2{}, data)
+	if err != nil {
+		return nil, err
+	}
+	return *(result.(*[]*CreateMessagesResultV2)), nil
+}
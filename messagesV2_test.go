@@ -1,6 +1,7 @@
 package bandwidth
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 )
@@ -51,3 +52,74 @@ func TestCreateMessageV2Fail(t *testing.T) {
 		return api.CreateMessageV2(&CreateMessageDataV2{From: "fromNumber", To: "toNumber", Text: "text"})
 	})
 }
+
+func TestCreateMessagesV2(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v2/users/userId/messages",
+		Method:           http.MethodPost,
+		EstimatedContent: `[{"from":"fromNumber","to":"toNumber1","text":"text"},{"from":"fromNumber","to":"toNumber2","text":"text"}]`,
+		ContentToSend: `[{
+			"id": "14762070468292kw2fuqty55yp2b2",
+			"time": "2016-09-14T18:20:16Z",
+			"from": "fromNumber",
+			"to": "toNumber1",
+			"text": "text",
+			"direction": "out",
+			"segmentCount": 1
+		}, {
+			"error": {"code": "invalid-to", "message": "Invalid destination number"}
+		}]`}})
+	defer server.Close()
+	results, err := api.CreateMessagesV2(&CreateMessageDataV2{From: "fromNumber", To: "toNumber1", Text: "text"},
+		&CreateMessageDataV2{From: "fromNumber", To: "toNumber2", Text: "text"})
+	if err != nil {
+		t.Fatal("Failed call of CreateMessagesV2()")
+	}
+	expect(t, len(results), 2)
+	expect(t, results[0].ID, "14762070468292kw2fuqty55yp2b2")
+	if results[0].Error != nil {
+		t.Error("Should not contain error for sent message")
+	}
+	expect(t, results[1].ID, "")
+	expect(t, results[1].Error.Code, "invalid-to")
+	expect(t, results[1].Error.Error(), "Invalid destination number")
+}
+
+func TestCreateMessagesV2WithNumericErrorCode(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v2/users/userId/messages",
+		Method:        http.MethodPost,
+		ContentToSend: `[{"id": "123", "to": "toNumber1"}, {"error": {"code": 4720, "message": "Invalid destination number"}}, {"error": {"code": 4730}}]`}})
+	defer server.Close()
+	results, err := api.CreateMessagesV2(&CreateMessageDataV2{From: "fromNumber", To: "toNumber1", Text: "text"},
+		&CreateMessageDataV2{From: "fromNumber", To: "toNumber2", Text: "text"},
+		&CreateMessageDataV2{From: "fromNumber", To: "toNumber3", Text: "text"})
+	if err != nil {
+		t.Fatal("Failed call of CreateMessagesV2()")
+	}
+	expect(t, len(results), 3)
+	expect(t, results[0].ID, "123")
+	if results[0].Error != nil {
+		t.Error("Should not contain error for sent message")
+	}
+	expect(t, results[1].Error.Code, "4720")
+	expect(t, results[1].Error.Error(), "Invalid destination number")
+	expect(t, results[2].Error.Error(), "4730")
+}
+
+func TestMessageErrorV2UnmarshalJSONFail(t *testing.T) {
+	if json.Unmarshal([]byte(`{"code": true}`), &MessageErrorV2{}) == nil {
+		t.Error("Should fail here")
+	}
+}
+
+func TestCreateMessagesV2Fail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v2/users/userId/messages",
+		Method:           http.MethodPost,
+		StatusCodeToSend: http.StatusBadRequest}})
+	defer server.Close()
+	shouldFail(t, func() (interface{}, error) {
+		return api.CreateMessagesV2(&CreateMessageDataV2{From: "fromNumber", To: "toNumber", Text: "text"})
+	})
+}
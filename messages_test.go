@@ -112,7 +112,8 @@ func TestGetMessage(t *testing.T) {
 		Method:       http.MethodGet,
 		ContentToSend: `{
 			"id": "{messageId1}",
-			"text": "message1"
+			"text": "message1",
+			"time": "2012-10-05T20:37:38.048Z"
 		}`}})
 	defer server.Close()
 	result, err := api.GetMessage("123")
@@ -121,6 +122,7 @@ func TestGetMessage(t *testing.T) {
 		return
 	}
 	expect(t, result.ID, "{messageId1}")
+	expect(t, result.Time, "2012-10-05T20:37:38.048Z")
 }
 
 func TestGetMessageFail(t *testing.T) {
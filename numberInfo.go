@@ -1,9 +1,10 @@
 package bandwidth
 
 import (
+	"encoding/json"
 	"fmt"
-	"net/http"
 	"net/url"
+	"time"
 )
 
 const numberInfoPath = "phoneNumbers/numberInfo"
@@ -14,14 +15,23 @@ type NumberInfo struct {
 	Name    string `json:"name"`
 	Created string `json:"created"`
 	Updated string `json:"updated"`
+	// CreatedTime and UpdatedTime are parsed Created and Updated (zero if the value is missing or malformed)
+	CreatedTime time.Time `json:"-"`
+	UpdatedTime time.Time `json:"-"`
+}
+
+// UnmarshalJSON decodes the number info and fills its typed times
+func (i *NumberInfo) UnmarshalJSON(data []byte) error {
+	type rawNumberInfo NumberInfo
+	if err := json.Unmarshal(data, (*rawNumberInfo)(i)); err != nil {
+		return err
+	}
+	i.CreatedTime, i.UpdatedTime = parseTime(i.Created), parseTime(i.Updated)
+	return nil
 }
 
 // GetNumberInfo returns information fo given number
 // It returns NumberInfo instance or error
 func (api *Client) GetNumberInfo(number string) (*NumberInfo, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s", numberInfoPath, url.QueryEscape(number)), &NumberInfo{})
-	if err != nil {
-		return nil, err
-	}
-	return result.(*NumberInfo), nil
+	return getTyped[NumberInfo](api, fmt.Sprintf("%s/%s", numberInfoPath, url.QueryEscape(number)), nil)
 }
@@ -1,8 +1,10 @@
 package bandwidth
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestGetNumberInfo(t *testing.T) {
@@ -22,6 +24,18 @@ func TestGetNumberInfo(t *testing.T) {
 		return
 	}
 	expect(t, result.Number, "123")
+	expect(t, result.Created, "2013-09-23T16:31:15Z")
+	expect(t, result.Updated, "2013-09-23T16:42:18Z")
+	expect(t, result.CreatedTime, time.Date(2013, 9, 23, 16, 31, 15, 0, time.UTC))
+	expect(t, result.UpdatedTime, time.Date(2013, 9, 23, 16, 42, 18, 0, time.UTC))
+}
+
+func TestNumberInfoWithoutTimes(t *testing.T) {
+	info := &NumberInfo{}
+	expectNil(t, json.Unmarshal([]byte(`{"number": "123", "created": "yesterday"}`), info))
+	expect(t, info.Number, "123")
+	expect(t, info.CreatedTime.IsZero(), true)
+	expect(t, info.UpdatedTime.IsZero(), true)
 }
 
 func TestGetNumberInfoFail(t *testing.T) {
@@ -0,0 +1,143 @@
+package bandwidth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Pagination contains paging data of a list response
+type Pagination struct {
+	// Page is requested page number (starting from 0)
+	Page int
+	// Size is page size (-1 if it was neither requested nor returned by the API)
+	Size int
+	// Total is total count of items (-1 if the API didn't return it)
+	Total int
+	// NextURL is url of next page ("" for last page)
+	NextURL string
+}
+
+// HasNextPage returns true if there are more items after this page
+func (p *Pagination) HasNextPage() bool {
+	return p.NextURL != ""
+}
+
+// parseLinkHeader returns urls of Link header values by their rel
+// example: parseLinkHeader([]string{`<https://host/path?page=1>; rel="next"`})["next"] == "https://host/path?page=1"
+func parseLinkHeader(values []string) map[string]string {
+	links := map[string]string{}
+	for _, value := range values {
+		for _, link := range strings.Split(value, ",") {
+			parts := strings.Split(link, ";")
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			target = target[1 : len(target)-1]
+			for _, param := range parts[1:] {
+				pair := strings.SplitN(strings.TrimSpace(param), "=", 2)
+				if len(pair) != 2 || strings.ToLower(strings.TrimSpace(pair[0])) != "rel" {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(pair[1]), `"`)) {
+					links[strings.ToLower(rel)] = target
+				}
+			}
+		}
+	}
+	return links
+}
+
+// parsePagination builds Pagination from the sent query and headers of the list response
+func parsePagination(query url.Values, headers http.Header) *Pagination {
+	pagination := &Pagination{Size: -1, Total: -1}
+	pagination.Page, _ = strconv.Atoi(query.Get("page"))
+	if size, err := strconv.Atoi(query.Get("size")); err == nil {
+		pagination.Size = size
+	}
+	if total, err := strconv.Atoi(headers.Get("X-Total-Count")); err == nil {
+		pagination.Total = total
+	}
+	pagination.NextURL = parseLinkHeader(headers["Link"])["next"]
+	if pagination.Size < 0 && pagination.NextURL != "" {
+		// the API echoes page size in links
+		if next, err := url.Parse(pagination.NextURL); err == nil {
+			if size, err := strconv.Atoi(next.Query().Get("size")); err == nil {
+				pagination.Size = size
+			}
+		}
+	}
+	return pagination
+}
+
+// getListPage requests a page of list resource
+// It returns pointer to filled out and pagination data of the page or error
+func (c *Client) getListPage(path string, out interface{}, query interface{}) (interface{}, *Pagination, error) {
+	result, headers, err := c.makeRequest(http.MethodGet, path, out, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, parsePagination(encodeQuery(query), headers), nil
+}
+
+// getAllPages requests all pages of list resource (following next links of the responses) and appends their items to out (pointer to slice)
+// Query parameters of next pages are taken from next links, so host and path of the links don't matter.
+// It returns error object
+func (c *Client) getAllPages(path string, query, out interface{}) error {
+	outValue := reflect.ValueOf(out)
+	if outValue.Kind() != reflect.Ptr || outValue.IsNil() || outValue.Elem().Kind() != reflect.Slice {
+		return errors.New("Pointer to slice is expected for list items")
+	}
+	list := outValue.Elem()
+	visited := map[string]bool{encodeQuery(query).Encode(): true}
+	for {
+		page := reflect.New(list.Type())
+		result, pagination, err := c.getListPage(path, page.Interface(), query)
+		if err != nil {
+			return err
+		}
+		list.Set(reflect.AppendSlice(list, reflect.ValueOf(result).Elem()))
+		nextQuery, err := nextPageQuery(pagination, visited)
+		if err != nil || nextQuery == nil {
+			return err
+		}
+		query = nextQuery
+	}
+}
+
+// nextPageQuery returns query of next page from next link of the page (nil for last page or already visited next page)
+// Pages are identified by their query (next links can point to the same page with other host), visited pages are marked in visited.
+func nextPageQuery(pagination *Pagination, visited map[string]bool) (map[string]string, error) {
+	if !pagination.HasNextPage() {
+		return nil, nil
+	}
+	next, err := url.Parse(pagination.NextURL)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid url of next page %q", pagination.NextURL)
+	}
+	values := next.Query()
+	if visited[values.Encode()] {
+		return nil, nil
+	}
+	visited[values.Encode()] = true
+	query := map[string]string{}
+	for key := range values {
+		query[key] = values.Get(key)
+	}
+	return query, nil
+}
+
+// listAllTyped requests all pages of list resource of type T (see getAllPages())
+// It returns list of T instances or error
+func listAllTyped[T any](c *Client, path string, query interface{}) ([]*T, error) {
+	list := []*T{}
+	if err := c.getAllPages(path, query, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,139 @@
+package bandwidth
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	links := parseLinkHeader([]string{
+		`<https://host/v1/calls?page=0&size=25>; rel="first", <https://host/v1/calls?page=2&size=25>;rel="next"`,
+		` <https://host/v1/calls?page=0>  ;  REL = "previous prev" `,
+		`invalid; rel="last"`,
+	})
+	expect(t, len(links), 4)
+	expect(t, links["first"], "https://host/v1/calls?page=0&size=25")
+	expect(t, links["next"], "https://host/v1/calls?page=2&size=25")
+	expect(t, links["previous"], "https://host/v1/calls?page=0")
+	expect(t, links["prev"], "https://host/v1/calls?page=0")
+	expect(t, len(parseLinkHeader(nil)), 0)
+}
+
+func TestParsePagination(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("Link", `<https://host/v1/calls?page=2&size=25>; rel="next"`)
+	headers.Set("X-Total-Count", "60")
+	pagination := parsePagination(url.Values{"page": []string{"1"}}, headers)
+	expect(t, pagination.Page, 1)
+	expect(t, pagination.Size, 25)
+	expect(t, pagination.Total, 60)
+	expect(t, pagination.HasNextPage(), true)
+	expect(t, pagination.NextURL, "https://host/v1/calls?page=2&size=25")
+	pagination = parsePagination(url.Values{"size": []string{"10"}}, headers)
+	expect(t, pagination.Size, 10)
+}
+
+func TestParsePaginationWithoutData(t *testing.T) {
+	pagination := parsePagination(url.Values{}, http.Header{})
+	expect(t, *pagination, Pagination{Page: 0, Size: -1, Total: -1})
+	expect(t, pagination.HasNextPage(), false)
+}
+
+func TestGetCallsPage(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: "/v1/users/userId/calls?page=1&size=2",
+		Method:       http.MethodGet,
+		HeadersToSend: map[string]string{
+			"Link":          `<https://host/v1/users/userId/calls?page=2&size=2>; rel="next"`,
+			"X-Total-Count": "5"},
+		ContentToSend: `[{"id": "1"}, {"id": "2"}]`}})
+	defer server.Close()
+	list, pagination, err := api.GetCallsPage(&GetCallsQuery{Page: 1, Size: 2})
+	if err != nil {
+		t.Fatal("Failed call of GetCallsPage()")
+	}
+	expect(t, len(list), 2)
+	expect(t, pagination.Page, 1)
+	expect(t, pagination.Size, 2)
+	expect(t, pagination.Total, 5)
+	expect(t, pagination.HasNextPage(), true)
+}
+
+func TestGetDomainEndpointsPage(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/domains/123/endpoints",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "1"}]`}})
+	defer server.Close()
+	list, pagination, err := api.GetDomainEndpointsPage("123")
+	if err != nil {
+		t.Fatal("Failed call of GetDomainEndpointsPage()")
+	}
+	expect(t, len(list), 1)
+	expect(t, pagination.HasNextPage(), false)
+	expect(t, pagination.Total, -1)
+}
+
+func TestGetMessagesPageFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/messages",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusBadRequest}})
+	defer server.Close()
+	_, _, err := api.GetMessagesPage()
+	if err == nil {
+		t.Error("Should fail here")
+	}
+}
+
+func TestGetAllPages(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls?size=2",
+		Method:        http.MethodGet,
+		HeadersToSend: map[string]string{"Link": `<https://host/v1/users/userId/calls?page=1&size=2>; rel="next"`},
+		ContentToSend: `[{"id": "1"}, {"id": "2"}]`}, RequestHandler{
+		PathAndQuery: "/v1/users/userId/calls?page=1&size=2",
+		Method:       http.MethodGet,
+		HeadersToSend: map[string]string{
+			"Link": `<https://host/v1/users/userId/calls?page=0&size=2>; rel="first" ,  <https://host/v1/users/userId/calls?page=2&size=2>;rel="next"`},
+		ContentToSend: `[{"id": "3"}, {"id": "4"}]`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls?page=2&size=2",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "5"}]`}})
+	defer server.Close()
+	list := []*Call{}
+	expectNil(t, api.getAllPages("/users/userId/calls", map[string]string{"size": "2"}, &list))
+	expect(t, len(list), 5)
+	expect(t, list[4].ID, "5")
+	calls, err := api.GetAllCalls(&GetCallsQuery{Size: 2})
+	expectNil(t, err)
+	expect(t, len(calls), 5)
+}
+
+func TestGetAllPagesWithCyclicLinks(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/messages?page=1",
+		Method:        http.MethodGet,
+		HeadersToSend: map[string]string{"Link": `<https://host/v1/users/userId/messages?page=1>; rel="next"`},
+		ContentToSend: `[{"id": "1"}]`}})
+	defer server.Close()
+	list, err := api.GetAllMessages(&GetMessagesQuery{Page: 1})
+	expectNil(t, err)
+	expect(t, len(list), 1)
+}
+
+func TestGetAllPagesFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/recordings",
+		Method:        http.MethodGet,
+		HeadersToSend: map[string]string{"Link": `<https://host/v1/users/userId/recordings?page=1>; rel="next"`},
+		ContentToSend: `[{"id": "1"}]`}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/recordings?page=1",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusBadRequest}})
+	defer server.Close()
+	shouldFail(t, func() (interface{}, error) { return api.GetAllRecordings() })
+	var notSlice []*Call
+	shouldFail(t, func() (interface{}, error) { return nil, api.getAllPages("/users/userId/calls", nil, notSlice) })
+}
@@ -40,9 +40,12 @@ type PhoneNumberProvider struct {
 
 // UpdatePhoneNumberData struct
 type UpdatePhoneNumberData struct {
-	Name           string `json:"name,omitempty"`
-	ApplicationID  string `json:"applicationId,omitempty"`
-	FallbackNumber string `json:"fallbackNumber,omitempty"`
+	Name           string `json:"name,omitempty" clearable:"true"`
+	ApplicationID  string `json:"applicationId,omitempty" clearable:"true"`
+	FallbackNumber string `json:"fallbackNumber,omitempty" clearable:"true"`
+	// ClearFields lists Go names of fields which should be cleared (sent as null)
+	// Supported fields: Name, ApplicationID, FallbackNumber
+	ClearFields []string `json:"-"`
 }
 
 // GetPhoneNumbersQuery is optional parameters of GetPhoneNumbers()
@@ -54,40 +57,46 @@ type GetPhoneNumbersQuery struct {
 	Name          string
 	City          string
 	NumberState   string
+	// QueryParams are raw query parameters (see QueryParams type)
+	QueryParams QueryParams
 }
 
 // GetPhoneNumbers returns a list of your numbers
 // It returns list of PhoneNumber instances or error
 func (api *Client) GetPhoneNumbers(query ...*GetPhoneNumbersQuery) ([]*PhoneNumber, error) {
+	list, _, err := api.GetPhoneNumbersPage(query...)
+	return list, err
+}
+
+// GetPhoneNumbersPage returns a page of your numbers like GetPhoneNumbers() with pagination data of the page
+// It returns list of PhoneNumber instances, Pagination instance or error
+// example: list, pagination, err := api.GetPhoneNumbersPage(&bandwidth.GetPhoneNumbersQuery{Page: 1})
+func (api *Client) GetPhoneNumbersPage(query ...*GetPhoneNumbersQuery) ([]*PhoneNumber, *Pagination, error) {
 	var options *GetPhoneNumbersQuery
 	if len(query) > 0 {
 		options = query[0]
 	}
-	result, _, err := api.makeRequest(http.MethodGet, api.concatUserPath(phoneNumbersPath), &[]*PhoneNumber{}, options)
+	result, pagination, err := api.getListPage(api.concatUserPath(phoneNumbersPath), &[]*PhoneNumber{}, options)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return *(result.(*[]*PhoneNumber)), nil
+	return *(result.(*[]*PhoneNumber)), pagination, nil
 }
 
 // CreatePhoneNumber creates a new phone number
 // It returns ID of created phone number or error
 func (api *Client) CreatePhoneNumber(data *CreatePhoneNumberData) (string, error) {
-	_, headers, err := api.makeRequest(http.MethodPost, api.concatUserPath(phoneNumbersPath), nil, data)
+	result, headers, err := api.makeRequest(http.MethodPost, api.concatUserPath(phoneNumbersPath), nil, data)
 	if err != nil {
 		return "", err
 	}
-	return getIDFromLocationHeader(headers), nil
+	return getCreatedID(result, headers)
 }
 
 // GetPhoneNumber returns information for phone number by id or number
 // It returns instance of PhoneNumber or error
 func (api *Client) GetPhoneNumber(idOrNumber string) (*PhoneNumber, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s", api.concatUserPath(phoneNumbersPath), url.QueryEscape(idOrNumber)), &PhoneNumber{})
-	if err != nil {
-		return nil, err
-	}
-	return result.(*PhoneNumber), nil
+	return getTyped[PhoneNumber](api, fmt.Sprintf("%s/%s", api.concatUserPath(phoneNumbersPath), url.QueryEscape(idOrNumber)), nil)
 }
 
 // UpdatePhoneNumber makes changes to your number
@@ -97,9 +106,9 @@ func (api *Client) UpdatePhoneNumber(idOrNumber string, data *UpdatePhoneNumberD
 	return err
 }
 
-// DeletePhoneNumber removes a phone number
+// DeletePhoneNumber removes a phone number (by id or number)
 // It returns error object
-func (api *Client) DeletePhoneNumber(id string) error {
-	_, _, err := api.makeRequest(http.MethodDelete, fmt.Sprintf("%s/%s", api.concatUserPath(phoneNumbersPath), id))
+func (api *Client) DeletePhoneNumber(idOrNumber string) error {
+	_, _, err := api.makeRequest(http.MethodDelete, fmt.Sprintf("%s/%s", api.concatUserPath(phoneNumbersPath), url.QueryEscape(idOrNumber)))
 	return err
 }
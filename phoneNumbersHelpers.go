@@ -0,0 +1,185 @@
+package bandwidth
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var tollFreeAreaCodes = []string{"800", "833", "844", "855", "866", "877", "888"}
+
+// InventorySummary contains counts of the user's phone numbers
+type InventorySummary struct {
+	Total       int            `json:"total"`
+	Local       int            `json:"local"`
+	TollFree    int            `json:"tollFree"`
+	ByState     map[string]int `json:"byState"`
+	Assigned    int            `json:"assigned"`
+	NotAssigned int            `json:"notAssigned"`
+}
+
+// GetNumberInventorySummary counts the user's phone numbers by type (local/toll free), number state and application assignment
+// There is no summary endpoint in the API, so the summary is built on client side by paging through the whole
+// phone number list (one request per page of 1000 numbers). Cache the result if you need it often.
+// It returns InventorySummary instance or error
+func (api *Client) GetNumberInventorySummary() (*InventorySummary, error) {
+	summary := &InventorySummary{ByState: map[string]int{}}
+	list, err := listAllTyped[PhoneNumber](api, api.concatUserPath(phoneNumbersPath), &GetPhoneNumbersQuery{Size: maxPageSize})
+	if err != nil {
+		return nil, err
+	}
+	for _, number := range list {
+		summary.Total++
+		if isTollFreeNumber(number.Number) {
+			summary.TollFree++
+		} else {
+			summary.Local++
+		}
+		if number.NumberState != "" {
+			summary.ByState[number.NumberState]++
+		}
+		if number.ApplicationID != "" {
+			summary.Assigned++
+		} else {
+			summary.NotAssigned++
+		}
+	}
+	return summary, nil
+}
+
+// isTollFreeNumber returns true for US/Canada toll free numbers (E.164 with +1 country code or 10/11 digits without "+")
+// Numbers of other countries (like international toll free +800 ones) are not toll free here.
+func isTollFreeNumber(number string) bool {
+	switch {
+	case strings.HasPrefix(number, "+1"):
+		number = number[2:]
+	case strings.HasPrefix(number, "+"):
+		return false
+	case len(number) == 11 && number[0] == '1':
+		number = number[1:]
+	case len(number) != 10:
+		return false
+	}
+	for _, code := range tollFreeAreaCodes {
+		if strings.HasPrefix(number, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// NumberType is type of phone number returned by ClassifyNumber()
+type NumberType string
+
+const (
+	// NumberTypeLocal is regular (geographic or mobile) number
+	NumberTypeLocal NumberType = "local"
+
+	// NumberTypeTollFree is US/Canada toll free number
+	NumberTypeTollFree NumberType = "tollFree"
+
+	// NumberTypeShortCode is 5-6 digits short code (used for messaging)
+	NumberTypeShortCode NumberType = "shortCode"
+
+	// NumberTypeSIPURI is SIP URI like sip:user@domain (used for calls)
+	NumberTypeSIPURI NumberType = "sipUri"
+)
+
+var (
+	e164Pattern      = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+	shortCodePattern = regexp.MustCompile(`^[0-9]{5,6}$`)
+	sipURIPattern    = regexp.MustCompile(`(?i)^sips?:[^@\s]+@[^@\s]+$`)
+	numberSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+)
+
+// ParseNumber normalizes the number (see NormalizeNumber()) and classifies it (see ClassifyNumber()) in one step
+// It returns normalized number, its type or error
+// example: number, numberType, err := bandwidth.ParseNumber("(919) 555-1212") // "+19195551212", NumberTypeLocal
+func ParseNumber(input string) (string, NumberType, error) {
+	if isSIPURI(input) {
+		uri := strings.TrimSpace(input)
+		if !sipURIPattern.MatchString(uri) {
+			return "", "", fmt.Errorf("Invalid SIP URI %q (sip:user@domain is expected)", input)
+		}
+		return uri, NumberTypeSIPURI, nil
+	}
+	number := numberSeparators.Replace(strings.TrimSpace(input))
+	if shortCodePattern.MatchString(number) {
+		return number, NumberTypeShortCode, nil
+	}
+	if !strings.HasPrefix(number, "+") {
+		// US/Canada number without country code
+		switch {
+		case len(number) == 10:
+			number = "+1" + number
+		case len(number) == 11 && number[0] == '1':
+			number = "+" + number
+		}
+	}
+	if !e164Pattern.MatchString(number) {
+		return "", "", fmt.Errorf("Invalid phone number %q (E.164 format like +19195551212 is expected)", input)
+	}
+	if isTollFreeNumber(number) {
+		return number, NumberTypeTollFree, nil
+	}
+	return number, NumberTypeLocal, nil
+}
+
+// NormalizeNumber converts the number to E.164 format (10 digits numbers are treated as US/Canada ones)
+// Short codes and SIP URIs are returned as is.
+// It returns normalized number or error
+// example: number, err := bandwidth.NormalizeNumber("919-555-1212") // "+19195551212"
+func NormalizeNumber(number string) (string, error) {
+	normalized, _, err := ParseNumber(number)
+	return normalized, err
+}
+
+// ClassifyNumber returns type of the number (local, toll free, short code or SIP URI) by its pattern
+// It returns NumberType value or error for invalid number
+// example: numberType, err := bandwidth.ClassifyNumber("+18005551212") // NumberTypeTollFree
+func ClassifyNumber(number string) (NumberType, error) {
+	_, numberType, err := ParseNumber(number)
+	return numberType, err
+}
+
+func isSIPURI(value string) bool {
+	value = strings.ToLower(strings.TrimSpace(value))
+	return strings.HasPrefix(value, "sip:") || strings.HasPrefix(value, "sips:")
+}
+
+// UpdatePhoneNumberAndFetch makes changes to your number like UpdatePhoneNumber() and returns resulting state of the number
+// Use UpdatePhoneNumber() if you don't need the number data to avoid extra request.
+// It returns PhoneNumber instance or error
+func (api *Client) UpdatePhoneNumberAndFetch(idOrNumber string, data *UpdatePhoneNumberData) (*PhoneNumber, error) {
+	number := &PhoneNumber{}
+	if err := api.updateResourceAndFetch(fmt.Sprintf("%s/%s", api.concatUserPath(phoneNumbersPath), url.QueryEscape(idOrNumber)), data, number); err != nil {
+		return nil, err
+	}
+	return number, nil
+}
+
+// UpdatePhoneNumbers applies the same changes to many numbers (like UpdatePhoneNumber(), requests are made in parallel)
+// It returns map with result per id or number (nil for updated ones) and *BatchError if some numbers were not updated
+// example: results, err := api.UpdatePhoneNumbers([]string{"numberId1", "numberId2"}, &bandwidth.UpdatePhoneNumberData{ApplicationID: "appId"})
+func (api *Client) UpdatePhoneNumbers(ids []string, data *UpdatePhoneNumberData) (map[string]error, error) {
+	if data == nil {
+		return nil, errors.New("Missing changes of the numbers")
+	}
+	errs := api.runBatch(len(ids), func(i int) error {
+		return api.UpdatePhoneNumber(ids[i], data)
+	})
+	results := make(map[string]error, len(ids))
+	batchErr := &BatchError{Errors: map[string]error{}}
+	for i, id := range ids {
+		results[id] = errs[i]
+		if errs[i] != nil {
+			batchErr.Errors[id] = errs[i]
+		}
+	}
+	if len(batchErr.Errors) > 0 {
+		return results, batchErr
+	}
+	return results, nil
+}
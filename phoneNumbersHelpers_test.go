@@ -0,0 +1,194 @@
+package bandwidth
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetNumberInventorySummary(t *testing.T) {
+	maxPageSize = 2
+	defer func() { maxPageSize = 1000 }()
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/phoneNumbers?size=2",
+		Method:        http.MethodGet,
+		HeadersToSend: map[string]string{"Link": `<https://api.catapult.inetwork.com/v1/users/userId/phoneNumbers?page=1&size=2>; rel="next"`},
+		ContentToSend: `[{
+			"id": "{phoneNumberId1}",
+			"number": "+19195551212",
+			"numberState": "enabled",
+			"applicationId": "{applicationId}"
+		}, {
+			"id": "{phoneNumberId2}",
+			"number": "+18005551212",
+			"numberState": "enabled"
+		}]`}, RequestHandler{
+		PathAndQuery: "/v1/users/userId/phoneNumbers?page=1&size=2",
+		Method:       http.MethodGet,
+		ContentToSend: `[{
+			"id": "{phoneNumberId3}",
+			"number": "+19195551213",
+			"numberState": "released"
+		}, {
+			"id": "{phoneNumberId4}",
+			"number": "+80012345678",
+			"numberState": "enabled"
+		}]`}})
+	defer server.Close()
+	summary, err := api.GetNumberInventorySummary()
+	if err != nil {
+		t.Error("Failed call of GetNumberInventorySummary()")
+		return
+	}
+	expect(t, summary.Total, 4)
+	expect(t, summary.Local, 3)
+	expect(t, summary.TollFree, 1)
+	expect(t, summary.ByState, map[string]int{"enabled": 3, "released": 1})
+	expect(t, summary.Assigned, 1)
+	expect(t, summary.NotAssigned, 3)
+}
+
+func TestIsTollFreeNumber(t *testing.T) {
+	cases := map[string]bool{
+		"+18005551212":  true,
+		"18885551212":   true,
+		"8775551212":    true,
+		"+19195551212":  false,
+		"+80012345678":  false,
+		"+8005551212":   false,
+		"80012345678":   false,
+		"+448005551212": false,
+	}
+	for number, tollFree := range cases {
+		if isTollFreeNumber(number) != tollFree {
+			t.Errorf("Unexpected result for %s", number)
+		}
+	}
+}
+
+func TestGetNumberInventorySummaryFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/phoneNumbers?size=1000",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusBadRequest}})
+	defer server.Close()
+	shouldFail(t, func() (interface{}, error) { return api.GetNumberInventorySummary() })
+}
+
+func TestUpdatePhoneNumberAndFetch(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/phoneNumbers/%2B1234567890",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"applicationId":"456"}`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/phoneNumbers/%2B1234567890",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "123", "number": "+1234567890", "applicationId": "456"}`}})
+	defer server.Close()
+	number, err := api.UpdatePhoneNumberAndFetch("+1234567890", &UpdatePhoneNumberData{ApplicationID: "456"})
+	if err != nil {
+		t.Error("Failed call of UpdatePhoneNumberAndFetch()")
+		return
+	}
+	expect(t, number.ApplicationID, "456")
+}
+
+func TestUpdatePhoneNumberAndFetchFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/phoneNumbers/123",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"applicationId":"456"}`}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/phoneNumbers/123",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusNotFound}})
+	defer server.Close()
+	shouldFail(t, func() (interface{}, error) {
+		return api.UpdatePhoneNumberAndFetch("123", &UpdatePhoneNumberData{ApplicationID: "456"})
+	})
+}
+
+func TestUpdatePhoneNumbers(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/phoneNumbers/1",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"applicationId":"appId"}`}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/phoneNumbers/2",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"applicationId":"appId"}`}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/phoneNumbers/3",
+		Method:           http.MethodPost,
+		StatusCodeToSend: http.StatusNotFound}})
+	defer server.Close()
+	results, err := api.UpdatePhoneNumbers([]string{"1", "2", "3"}, &UpdatePhoneNumberData{ApplicationID: "appId"})
+	expect(t, len(results), 3)
+	expectNil(t, results["1"])
+	expectNil(t, results["2"])
+	if results["3"] == nil {
+		t.Error("Should contain error for number 3")
+	}
+	batchErr, ok := err.(*BatchError)
+	if !ok {
+		t.Fatal("Should return BatchError")
+	}
+	expect(t, len(batchErr.Errors), 1)
+	results, err = api.UpdatePhoneNumbers([]string{"1", "2"}, &UpdatePhoneNumberData{ApplicationID: "appId"})
+	expectNil(t, err)
+	expect(t, len(results), 2)
+}
+
+func TestUpdatePhoneNumbersFail(t *testing.T) {
+	api := getAPI()
+	shouldFail(t, func() (interface{}, error) { return api.UpdatePhoneNumbers([]string{"1"}, nil) })
+}
+
+func TestParseNumber(t *testing.T) {
+	cases := []struct {
+		input      string
+		number     string
+		numberType NumberType
+	}{
+		{"+19195551212", "+19195551212", NumberTypeLocal},
+		{"(919) 555-1212", "+19195551212", NumberTypeLocal},
+		{"1.919.555.1212", "+19195551212", NumberTypeLocal},
+		{"+1 800 555 1212", "+18005551212", NumberTypeTollFree},
+		{"8885551212", "+18885551212", NumberTypeTollFree},
+		{"+448005551212", "+448005551212", NumberTypeLocal},
+		{"+80012345678", "+80012345678", NumberTypeLocal},
+		{"12345", "12345", NumberTypeShortCode},
+		{" 123456 ", "123456", NumberTypeShortCode},
+		{"sip:john@example.com", "sip:john@example.com", NumberTypeSIPURI},
+		{"SIP:john@example.com", "SIP:john@example.com", NumberTypeSIPURI},
+		{"SIPS:john@example.com", "SIPS:john@example.com", NumberTypeSIPURI},
+		{"sips:1-919-555-1212@sip.example.com:5061", "sips:1-919-555-1212@sip.example.com:5061", NumberTypeSIPURI},
+	}
+	for _, c := range cases {
+		number, numberType, err := ParseNumber(c.input)
+		expectNil(t, err)
+		expect(t, number, c.number)
+		expect(t, numberType, c.numberType)
+	}
+}
+
+func TestParseNumberFail(t *testing.T) {
+	for _, number := range []string{"", "abc", "+0123456", "1234", "919555121", "+1234567890123456", "sip:", "sip:john", "sip:@example.com", "sip:john doe@example.com"} {
+		if _, _, err := ParseNumber(number); err == nil {
+			t.Errorf("Should fail for %q", number)
+		}
+	}
+}
+
+func TestNormalizeNumber(t *testing.T) {
+	number, err := NormalizeNumber("919-555-1212")
+	expectNil(t, err)
+	expect(t, number, "+19195551212")
+	shouldFail(t, func() (interface{}, error) { return NormalizeNumber("invalid") })
+}
+
+func TestClassifyNumber(t *testing.T) {
+	numberType, err := ClassifyNumber("+18005551212")
+	expectNil(t, err)
+	expect(t, numberType, NumberTypeTollFree)
+	numberType, _ = ClassifyNumber("+19195551212")
+	expect(t, numberType, NumberTypeLocal)
+	numberType, _ = ClassifyNumber("55555")
+	expect(t, numberType, NumberTypeShortCode)
+	shouldFail(t, func() (interface{}, error) { return ClassifyNumber("12") })
+}
@@ -86,7 +86,8 @@ func TestGetPhoneNumber(t *testing.T) {
 		Method:       http.MethodGet,
 		ContentToSend: `{
 			"id": "123",
-			"number": "phoneNumber1"
+			"number": "phoneNumber1",
+			"createdTime": "2013-02-13T17:46:08.374Z"
 		}`}})
 	defer server.Close()
 	result, err := api.GetPhoneNumber("123")
@@ -95,6 +96,7 @@ func TestGetPhoneNumber(t *testing.T) {
 		return
 	}
 	expect(t, result.ID, "123")
+	expect(t, result.CreatedTime, "2013-02-13T17:46:08.374Z")
 }
 
 func TestGetPhoneNumberFail(t *testing.T) {
@@ -132,3 +134,11 @@ func TestDeletePhoneNumber(t *testing.T) {
 		return
 	}
 }
+
+func TestDeletePhoneNumberByNumber(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: "/v1/users/userId/phoneNumbers/%2B19195551212",
+		Method:       http.MethodDelete}})
+	defer server.Close()
+	expectNil(t, api.DeletePhoneNumber("+19195551212"))
+}
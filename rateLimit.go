@@ -0,0 +1,157 @@
+package bandwidth
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// rateLimitState is rate limit data of the last response
+type rateLimitState struct {
+	known     bool
+	remaining int
+	reset     time.Time
+}
+
+// RateLimit is rate limit data of API response (from X-RateLimit-* headers)
+type RateLimit struct {
+	// Limit is count of requests allowed in the window (zero if the API didn't return it)
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// parseRateLimit reads rate limit headers of the response
+// It returns nil if the headers are missing or malformed
+func parseRateLimit(headers http.Header) *RateLimit {
+	remaining, err := strconv.Atoi(headers.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return nil
+	}
+	if _, err := strconv.ParseInt(headers.Get("X-RateLimit-Reset"), 10, 64); err != nil {
+		return nil
+	}
+	limit := 0
+	if value := headers.Get("X-RateLimit-Limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil {
+			return nil
+		}
+	}
+	return &RateLimit{Limit: limit, Remaining: remaining, Reset: parseRateLimitReset(headers)}
+}
+
+// LastRateLimit returns rate limit data of last response which had them (or nil if there were no such responses yet)
+// example: if limit := api.LastRateLimit(); limit != nil && limit.Remaining < 10 { ... }
+func (c *Client) LastRateLimit() *RateLimit {
+	state := c.state()
+	state.rateLimitMutex.Lock()
+	defer state.rateLimitMutex.Unlock()
+	if state.lastRateLimit == nil {
+		return nil
+	}
+	limit := *state.lastRateLimit
+	return &limit
+}
+
+// WithRateLimitCushion makes the client slow down requests when less than count requests
+// remain in current rate limit window (by X-RateLimit-Remaining header of last response).
+// Remaining requests are spread over the rest of the window instead of hitting 429 errors.
+// The pause is aborted when context of the request is done (see WithContext()).
+// example: api := bandwidth.New("userId", "apiToken", "apiSecret", bandwidth.WithRateLimitCushion(10))
+func WithRateLimitCushion(count int) Option {
+	return func(c *Client) error {
+		if count < 0 {
+			return fmt.Errorf("Invalid rate limit cushion %d", count)
+		}
+		c.RateLimitCushion = count
+		return nil
+	}
+}
+
+// WithRateLimitRetries makes the client retry requests failed with RateLimitError (up to count times) after the limit reset
+// The wait is aborted when context of the request is done (see WithContext()).
+// example: api := bandwidth.New("userId", "apiToken", "apiSecret", bandwidth.WithRateLimitRetries(3))
+func WithRateLimitRetries(count int) Option {
+	return func(c *Client) error {
+		if count < 0 {
+			return fmt.Errorf("Invalid rate limit retries count %d", count)
+		}
+		c.MaxRateLimitRetries = count
+		return nil
+	}
+}
+
+// maxRateLimitRetryJitter limits random delay added to reset time of the rate limit before retry
+// (so retries of concurrent requests don't hit the API at the same moment)
+const maxRateLimitRetryJitter = 250 * time.Millisecond
+
+func rateLimitRetryJitterDefault() time.Duration {
+	return time.Duration(rand.Int63n(int64(maxRateLimitRetryJitter)))
+}
+
+var rateLimitRetryJitter = rateLimitRetryJitterDefault
+
+// sleepContextDefault waits for given duration or until the context is done
+// It returns error of the context if the wait was aborted
+func sleepContextDefault(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+var sleepContext = sleepContextDefault
+
+// trackRateLimit remembers rate limit data of the response (if there are any)
+func (c *Client) trackRateLimit(headers http.Header) {
+	limit := parseRateLimit(headers)
+	if limit == nil {
+		return
+	}
+	state := c.state()
+	state.rateLimitMutex.Lock()
+	defer state.rateLimitMutex.Unlock()
+	state.lastRateLimit = limit
+	state.rateLimit = rateLimitState{known: true, remaining: limit.Remaining, reset: limit.Reset}
+}
+
+// rateLimitDelay returns how long next request should wait to keep within rate limit cushion
+func (c *Client) rateLimitDelay() time.Duration {
+	if c.RateLimitCushion <= 0 {
+		return 0
+	}
+	shared := c.state()
+	shared.rateLimitMutex.Lock()
+	defer shared.rateLimitMutex.Unlock()
+	state := &shared.rateLimit
+	if !state.known || state.remaining >= c.RateLimitCushion {
+		return 0
+	}
+	window := state.reset.Sub(timeNow())
+	if window <= 0 {
+		state.known = false
+		return 0
+	}
+	delay := window / time.Duration(state.remaining+1)
+	if state.remaining > 0 {
+		// concurrent requests should not wait for the same moment
+		state.remaining--
+	}
+	return delay
+}
+
+// waitForRateLimit pauses before a request if few requests remain in current rate limit window
+// It returns error of the context if it is done before the pause ends
+func (c *Client) waitForRateLimit(ctx context.Context) error {
+	if delay := c.rateLimitDelay(); delay > 0 {
+		return sleepContext(ctx, delay)
+	}
+	return nil
+}
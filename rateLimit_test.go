@@ -0,0 +1,216 @@
+package bandwidth
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestWithRateLimitCushion(t *testing.T) {
+	api, err := New("userId", "apiToken", "apiSecret", WithRateLimitCushion(10))
+	expectNil(t, err)
+	expect(t, api.RateLimitCushion, 10)
+	shouldFail(t, func() (interface{}, error) { return New("userId", "apiToken", "apiSecret", WithRateLimitCushion(-1)) })
+}
+
+func TestRateLimitDelay(t *testing.T) {
+	now := time.Date(2016, 11, 16, 15, 3, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+	api := getAPI()
+	headers := http.Header{}
+	headers.Set("Date", now.Format(http.TimeFormat))
+	headers.Set("X-RateLimit-Remaining", "1")
+	headers.Set("X-RateLimit-Reset", "1479308589000") // 9 seconds later
+	api.trackRateLimit(headers)
+	expect(t, api.rateLimitDelay(), time.Duration(0))
+	api.RateLimitCushion = 5
+	expect(t, api.rateLimitDelay(), 5*time.Second)
+	expect(t, api.rateLimitDelay(), 10*time.Second)
+	now = now.Add(time.Minute)
+	expect(t, api.rateLimitDelay(), time.Duration(0))
+	headers.Set("X-RateLimit-Remaining", "50")
+	api.trackRateLimit(headers)
+	expect(t, api.rateLimitDelay(), time.Duration(0))
+}
+
+func TestMakeRequestWithRateLimitCushion(t *testing.T) {
+	now := time.Date(2016, 11, 16, 15, 3, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	var delays []time.Duration
+	sleepContext = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	defer func() {
+		timeNow = time.Now
+		sleepContext = sleepContextDefault
+	}()
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: "/v1/test",
+		HeadersToSend: map[string]string{
+			"Date":                  "Wed, 16 Nov 2016 15:03:00 GMT",
+			"X-RateLimit-Remaining": "3",
+			"X-RateLimit-Reset":     "1479308599000"},
+		ContentToSend: `{}`}})
+	defer server.Close()
+	api.RateLimitCushion = 10
+	_, _, err := api.makeRequest(http.MethodGet, "/test")
+	expectNil(t, err)
+	expect(t, len(delays), 0)
+	_, _, err = api.makeRequest(http.MethodGet, "/test")
+	expectNil(t, err)
+	expect(t, delays, []time.Duration{5 * time.Second})
+}
+
+func TestMakeRequestWithRateLimitCushionAndCanceledContext(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: "/v1/test",
+		HeadersToSend: map[string]string{
+			"X-RateLimit-Remaining": "1",
+			"X-RateLimit-Reset":     strconv.FormatInt(time.Now().Add(time.Hour).Unix()*1000, 10)},
+		ContentToSend: `{}`}})
+	defer server.Close()
+	api.RateLimitCushion = 10
+	_, _, err := api.makeRequest(http.MethodGet, "/test")
+	expectNil(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, _, err = api.WithContext(ctx).makeRequest(http.MethodGet, "/test")
+	expect(t, err, context.DeadlineExceeded)
+	if time.Since(start) > 10*time.Second {
+		t.Error("Rate limit cushion should be aborted by the context")
+	}
+}
+
+func startRateLimitedServer(t *testing.T, limitedRequests int, estimatedBody string) (*httptest.Server, *int) {
+	count := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		body, _ := ioutil.ReadAll(r.Body)
+		expect(t, string(body), estimatedBody)
+		if count <= limitedRequests {
+			w.Header().Set("X-RateLimit-Reset", "1479308589000")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Location", "/v1/users/userId/calls/123")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	return server, &count
+}
+
+func TestMakeRequestWithRateLimitRetries(t *testing.T) {
+	var delays []time.Duration
+	sleepContext = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	rateLimitRetryJitter = func() time.Duration { return time.Millisecond }
+	defer func() {
+		sleepContext = sleepContextDefault
+		rateLimitRetryJitter = rateLimitRetryJitterDefault
+	}()
+	server, count := startRateLimitedServer(t, 2, `{"from":"fromNumber","to":"toNumber"}`)
+	defer server.Close()
+	api, _ := New("userId", "apiToken", "apiSecret", server.URL, WithRateLimitRetries(2))
+	id, err := api.CreateCall(&CreateCallData{From: "fromNumber", To: "toNumber"})
+	expectNil(t, err)
+	expect(t, id, "123")
+	expect(t, *count, 3)
+	expect(t, len(delays), 2)
+	expect(t, delays[0], time.Millisecond) // reset is in the past
+}
+
+func TestMakeRequestWithRateLimitRetriesFail(t *testing.T) {
+	sleepContext = func(ctx context.Context, d time.Duration) error { return nil }
+	defer func() { sleepContext = sleepContextDefault }()
+	server, count := startRateLimitedServer(t, 10, "")
+	defer server.Close()
+	api, _ := New("userId", "apiToken", "apiSecret", server.URL, WithRateLimitRetries(2))
+	_, err := api.GetCalls()
+	if _, ok := err.(*RateLimitError); !ok {
+		t.Errorf("Should return RateLimitError but got %v", err)
+	}
+	expect(t, *count, 3)
+	api.MaxRateLimitRetries = 0
+	api.GetCalls()
+	expect(t, *count, 4)
+	shouldFail(t, func() (interface{}, error) { return New("userId", "apiToken", "apiSecret", WithRateLimitRetries(-1)) })
+}
+
+func TestMakeRequestWithRateLimitRetriesAndCanceledContext(t *testing.T) {
+	rateLimitRetryJitter = func() time.Duration { return time.Hour }
+	defer func() { rateLimitRetryJitter = rateLimitRetryJitterDefault }()
+	server, count := startRateLimitedServer(t, 10, "")
+	defer server.Close()
+	api, _ := New("userId", "apiToken", "apiSecret", server.URL, WithRateLimitRetries(2))
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	_, err := api.WithContext(ctx).GetCalls()
+	expect(t, err, context.Canceled)
+	expect(t, *count, 1)
+	if time.Since(start) > 10*time.Second {
+		t.Error("Retry wait should be aborted")
+	}
+}
+
+func TestSleepContext(t *testing.T) {
+	expectNil(t, sleepContext(context.Background(), time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	expect(t, sleepContext(ctx, time.Hour), context.Canceled)
+}
+
+func TestLastRateLimit(t *testing.T) {
+	now := time.Date(2016, 11, 16, 15, 3, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls",
+		Method:        http.MethodGet,
+		ContentToSend: `[]`,
+		HeadersToSend: map[string]string{
+			"Date":                  now.Format(http.TimeFormat),
+			"X-RateLimit-Limit":     "100",
+			"X-RateLimit-Remaining": "99",
+			"X-RateLimit-Reset":     "1479308589000"}}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/account",
+		Method:        http.MethodGet,
+		ContentToSend: `{}`}})
+	defer server.Close()
+	if api.LastRateLimit() != nil {
+		t.Error("Should be nil before first request")
+	}
+	_, err := api.GetCalls()
+	expectNil(t, err)
+	expect(t, api.LastRateLimit(), &RateLimit{Limit: 100, Remaining: 99, Reset: now.Add(10 * time.Second)})
+	api.GetAccount() // response without rate limit headers
+	expect(t, api.LastRateLimit().Remaining, 99)
+}
+
+func TestParseRateLimit(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Remaining", "5")
+	headers.Set("X-RateLimit-Reset", "1479308589000")
+	expect(t, parseRateLimit(headers).Limit, 0)
+	expect(t, parseRateLimit(headers).Remaining, 5)
+	headers.Set("X-RateLimit-Limit", "many")
+	if parseRateLimit(headers) != nil {
+		t.Error("Should be nil for malformed limit")
+	}
+	headers.Set("X-RateLimit-Limit", "10")
+	headers.Set("X-RateLimit-Reset", "soon")
+	if parseRateLimit(headers) != nil {
+		t.Error("Should be nil for malformed reset")
+	}
+	if parseRateLimit(http.Header{}) != nil {
+		t.Error("Should be nil without headers")
+	}
+}
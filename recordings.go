@@ -1,8 +1,13 @@
 package bandwidth
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
 )
 
 const recordingsPath = "recordings"
@@ -15,34 +20,122 @@ type Recording struct {
 	Call      string `json:"call"`
 	StartTime string `json:"startTime"`
 	State     string `json:"state"`
+	// MediaFormat is "wav" or "mp3" (taken from media url if the API doesn't return it)
+	MediaFormat string `json:"mediaFormat"`
+	// Duration is computed from StartTime and EndTime (zero if some of them is missing or invalid)
+	Duration             time.Duration `json:"-"`
+	TranscriptionEnabled bool          `json:"transcriptionEnabled"`
+	// TranscriptionCost is total cost of transcriptions of the recording (parsed from number or string value)
+	TranscriptionCost float64 `json:"transcriptionCost"`
+}
+
+// UnmarshalJSON decodes the recording and fills its computed fields
+func (r *Recording) UnmarshalJSON(data []byte) error {
+	type rawRecording Recording
+	raw := struct {
+		*rawRecording
+		TranscriptionCost json.Number `json:"transcriptionCost"`
+	}{rawRecording: (*rawRecording)(r)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	r.TranscriptionCost = 0
+	if raw.TranscriptionCost != "" {
+		cost, err := raw.TranscriptionCost.Float64()
+		if err != nil {
+			return fmt.Errorf("Invalid transcription cost %q", raw.TranscriptionCost)
+		}
+		r.TranscriptionCost = cost
+	}
+	if r.MediaFormat == "" {
+		r.MediaFormat = recordingMediaFormat(r.Media)
+	}
+	start, end := parseTime(r.StartTime), parseTime(r.EndTime)
+	r.Duration = 0
+	if !start.IsZero() && end.After(start) {
+		r.Duration = end.Sub(start)
+	}
+	return nil
+}
+
+// recordingMediaFormat returns format of the recording by extension of media url
+func recordingMediaFormat(media string) string {
+	if u, err := url.Parse(media); err == nil {
+		media = u.Path
+	}
+	switch ext := strings.ToLower(path.Ext(media)); ext {
+	case ".wav", ".mp3":
+		return ext[1:]
+	}
+	return ""
 }
 
 // GetRecordingsQuery is optional parameters of GetRecordings()
 type GetRecordingsQuery struct {
 	Page int
 	Size int
+	// QueryParams are raw query parameters (see QueryParams type)
+	QueryParams QueryParams
 }
 
 // GetRecordings returns  a list of the calls recordings
 // It returns list of Recording instances or error
 func (api *Client) GetRecordings(query ...*GetRecordingsQuery) ([]*Recording, error) {
+	list, _, err := api.GetRecordingsPage(query...)
+	return list, err
+}
+
+// GetRecordingsPage returns a page of calls recordings like GetRecordings() with pagination data of the page
+// It returns list of Recording instances, Pagination instance or error
+// example: list, pagination, err := api.GetRecordingsPage(&bandwidth.GetRecordingsQuery{Page: 1})
+func (api *Client) GetRecordingsPage(query ...*GetRecordingsQuery) ([]*Recording, *Pagination, error) {
 	var options *GetRecordingsQuery
 	if len(query) > 0 {
 		options = query[0]
 	}
-	result, _, err := api.makeRequest(http.MethodGet, api.concatUserPath(recordingsPath), &[]*Recording{}, options)
+	result, pagination, err := api.getListPage(api.concatUserPath(recordingsPath), &[]*Recording{}, options)
 	if err != nil {
+		return nil, nil, err
+	}
+	return *(result.(*[]*Recording)), pagination, nil
+}
+
+// GetAllRecordings returns all calls recordings (requesting pages one by one while the API returns next page links)
+// It returns list of Recording instances or error
+func (api *Client) GetAllRecordings(query ...*GetRecordingsQuery) ([]*Recording, error) {
+	var options *GetRecordingsQuery
+	if len(query) > 0 {
+		options = query[0]
+	}
+	list := []*Recording{}
+	if err := api.getAllPages(api.concatUserPath(recordingsPath), options, &list); err != nil {
 		return nil, err
 	}
-	return *(result.(*[]*Recording)), nil
+	return list, nil
 }
 
 // GetRecording returns  a single call recording
 // It a Recording instance or error
 func (api *Client) GetRecording(id string) (*Recording, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s", api.concatUserPath(recordingsPath), id), &Recording{})
+	return getTyped[Recording](api, fmt.Sprintf("%s/%s", api.concatUserPath(recordingsPath), id), nil)
+}
+
+type updateRecordingData struct {
+	TranscriptionEnabled bool `json:"transcriptionEnabled"`
+}
+
+// SetTranscriptionEnabled turns on or off automatic transcription of finished recording
+// Only recordings in RecordingStateComplete state can be transcribed.
+// It returns error object
+// example: api.SetTranscriptionEnabled("recordingId", true)
+func (api *Client) SetTranscriptionEnabled(recordingID string, enabled bool) error {
+	recording, err := api.GetRecording(recordingID)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if recording.State != RecordingStateComplete {
+		return fmt.Errorf("Recording %s can't be transcribed in state %q", recordingID, recording.State)
 	}
-	return result.(*Recording), nil
+	_, _, err = api.makeRequest(http.MethodPost, fmt.Sprintf("%s/%s", api.concatUserPath(recordingsPath), recordingID), nil, &updateRecordingData{TranscriptionEnabled: enabled})
+	return err
 }
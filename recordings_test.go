@@ -1,8 +1,10 @@
 package bandwidth
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 )
 
 func TestGetRecordings(t *testing.T) {
@@ -60,7 +62,9 @@ func TestGetRecording(t *testing.T) {
 		Method:       http.MethodGet,
 		ContentToSend: `{
 			"id": "{recordingId1}",
-			"media": "recording1"
+			"media": "recording1",
+			"startTime": "2013-02-08T12:05:17.807Z",
+			"endTime": "2013-02-08T12:06:10.296Z"
 		}`}})
 	defer server.Close()
 	result, err := api.GetRecording("123")
@@ -69,6 +73,8 @@ func TestGetRecording(t *testing.T) {
 		return
 	}
 	expect(t, result.Media, "recording1")
+	expect(t, result.StartTime, "2013-02-08T12:05:17.807Z")
+	expect(t, result.EndTime, "2013-02-08T12:06:10.296Z")
 }
 
 func TestGetRecordingFail(t *testing.T) {
@@ -79,3 +85,99 @@ func TestGetRecordingFail(t *testing.T) {
 	defer server.Close()
 	shouldFail(t, func() (interface{}, error) { return api.GetRecording("123") })
 }
+
+func TestGetRecordingWithMediaFormatAndDuration(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: "/v1/users/userId/recordings/123",
+		Method:       http.MethodGet,
+		ContentToSend: `{
+			"id": "{recordingId1}",
+			"media": "https://api.catapult.inetwork.com/v1/users/{userId}/media/c-123-1.WAV",
+			"startTime": "2013-02-08T12:05:17.807Z",
+			"endTime": "2013-02-08T12:06:10.296Z"
+		}`}})
+	defer server.Close()
+	result, err := api.GetRecording("123")
+	if err != nil {
+		t.Error("Failed call of GetRecording()")
+		return
+	}
+	expect(t, result.MediaFormat, "wav")
+	expect(t, result.Duration, 52489*time.Millisecond)
+}
+
+func TestRecordingUnmarshalJSON(t *testing.T) {
+	recording := &Recording{}
+	expectNil(t, json.Unmarshal([]byte(`{"media": "file.bin", "mediaFormat": "mp3", "startTime": "2013-02-08T12:05:17Z"}`), recording))
+	expect(t, recording.MediaFormat, "mp3")
+	expect(t, recording.Duration, time.Duration(0))
+	recording = &Recording{}
+	expectNil(t, json.Unmarshal([]byte(`{"media": "file.bin", "startTime": "invalid", "endTime": "2013-02-08T12:05:17Z"}`), recording))
+	expect(t, recording.MediaFormat, "")
+	expect(t, recording.Duration, time.Duration(0))
+	recording = &Recording{}
+	expectNil(t, json.Unmarshal([]byte(`{"startTime": "2013-02-08T12:05:18Z", "endTime": "2013-02-08T12:05:17Z"}`), recording))
+	expect(t, recording.Duration, time.Duration(0))
+	if json.Unmarshal([]byte(`{"id": 1}`), recording) == nil {
+		t.Error("Should fail here")
+	}
+}
+
+func TestGetRecordingWithTranscription(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery: "/v1/users/userId/recordings/123",
+		Method:       http.MethodGet,
+		ContentToSend: `{
+			"id": "123",
+			"state": "complete",
+			"transcriptionEnabled": true,
+			"transcriptionCost": "0.05"
+		}`}})
+	defer server.Close()
+	result, err := api.GetRecording("123")
+	if err != nil {
+		t.Error("Failed call of GetRecording()")
+		return
+	}
+	expect(t, result.TranscriptionEnabled, true)
+	expect(t, result.TranscriptionCost, 0.05)
+}
+
+func TestRecordingUnmarshalJSONWithNumericTranscriptionCost(t *testing.T) {
+	recording := &Recording{}
+	expectNil(t, json.Unmarshal([]byte(`{"id": "123", "transcriptionCost": 0.05}`), recording))
+	expect(t, recording.ID, "123")
+	expect(t, recording.TranscriptionCost, 0.05)
+	if json.Unmarshal([]byte(`{"transcriptionCost": "free"}`), recording) == nil {
+		t.Error("Should fail here")
+	}
+}
+
+func TestSetTranscriptionEnabled(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/recordings/123",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "123", "state": "complete"}`}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/recordings/123",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"transcriptionEnabled":false}`}})
+	defer server.Close()
+	err := api.SetTranscriptionEnabled("123", false)
+	if err != nil {
+		t.Error("Failed call of SetTranscriptionEnabled()")
+		return
+	}
+}
+
+func TestSetTranscriptionEnabledFail(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/recordings/123",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "123", "state": "recording"}`}, RequestHandler{
+		PathAndQuery:     "/v1/users/userId/recordings/456",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusNotFound}})
+	defer server.Close()
+	shouldFail(t, func() (interface{}, error) { return nil, api.SetTranscriptionEnabled("123", true) })
+	shouldFail(t, func() (interface{}, error) { return nil, api.SetTranscriptionEnabled("456", true) })
+}
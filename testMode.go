@@ -0,0 +1,109 @@
+package bandwidth
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// TestModeError is returned by methods which call or text a number when test mode is enabled
+// and the number is not in Client.AllowedNumbers. Nothing is sent to the API in this case.
+type TestModeError struct {
+	Number string
+}
+
+func (e *TestModeError) Error() string {
+	return fmt.Sprintf("Test mode: number %s is not in allowed numbers, the request is not sent", e.Number)
+}
+
+// WithTestMode enables test mode: calls, transfers and messages are allowed to given numbers only
+// example: api := bandwidth.New("userId", "apiToken", "apiSecret", bandwidth.WithTestMode("+19195551212"))
+func WithTestMode(allowedNumbers ...string) Option {
+	return func(c *Client) error {
+		c.TestMode = true
+		c.AllowedNumbers = append(c.AllowedNumbers, allowedNumbers...)
+		return nil
+	}
+}
+
+// checkAllowedNumbers returns *TestModeError for first destination number which is not allowed in test mode
+func (c *Client) checkAllowedNumbers(numbers ...string) error {
+	if !c.TestMode {
+		return nil
+	}
+	for _, number := range numbers {
+		if !c.isAllowedNumber(number) {
+			return &TestModeError{Number: number}
+		}
+	}
+	return nil
+}
+
+func (c *Client) isAllowedNumber(number string) bool {
+	normalized, err := NormalizeNumber(number)
+	if err != nil {
+		normalized = number
+	}
+	for _, allowed := range c.AllowedNumbers {
+		if allowed == number {
+			return true
+		}
+		if normalizedAllowed, err := NormalizeNumber(allowed); err == nil && normalizedAllowed == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAllowedMessageV2Recipients checks destination numbers of V2 message (To is a number or list of numbers) in test mode
+// Recipients of unknown type can't be verified, so they are not allowed in test mode.
+func (c *Client) checkAllowedMessageV2Recipients(to interface{}) error {
+	if !c.TestMode {
+		return nil
+	}
+	numbers, err := messageV2Recipients(to)
+	if err != nil {
+		return err
+	}
+	return c.checkAllowedNumbers(numbers...)
+}
+
+// messageV2Recipients returns destination numbers of V2 message
+// To can be a string, a pointer to string or a list of them (including custom string types and []interface{})
+// It returns list of numbers or error for other types
+func messageV2Recipients(to interface{}) ([]string, error) {
+	if to == nil {
+		return nil, nil
+	}
+	value := reflect.ValueOf(to)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		numbers := make([]string, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			number, err := messageV2Recipient(value.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			numbers = append(numbers, number)
+		}
+		return numbers, nil
+	}
+	number, err := messageV2Recipient(value)
+	if err != nil {
+		return nil, err
+	}
+	return []string{number}, nil
+}
+
+func messageV2Recipient(value reflect.Value) (string, error) {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return "", errors.New("Test mode can't verify empty recipient")
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.String {
+		return "", fmt.Errorf("Test mode can't verify recipients of type %s", value.Type())
+	}
+	return value.String(), nil
+}
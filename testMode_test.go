@@ -0,0 +1,79 @@
+package bandwidth
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestWithTestMode(t *testing.T) {
+	api, err := New("userId", "apiToken", "apiSecret", WithTestMode("+19195551212", "+19195551213"))
+	expectNil(t, err)
+	expect(t, api.TestMode, true)
+	expect(t, api.AllowedNumbers, []string{"+19195551212", "+19195551213"})
+}
+
+func TestCheckAllowedNumbers(t *testing.T) {
+	api := getAPI()
+	expectNil(t, api.checkAllowedNumbers("+19195551214"))
+	api.TestMode = true
+	api.AllowedNumbers = []string{"(919) 555-1212", "sip:test@domain.com"}
+	expectNil(t, api.checkAllowedNumbers("+19195551212"))
+	expectNil(t, api.checkAllowedNumbers("9195551212", "sip:test@domain.com"))
+	err := api.checkAllowedNumbers("+19195551212", "+19195551214")
+	expect(t, err.(*TestModeError).Number, "+19195551214")
+	expect(t, err.Error(), "Test mode: number +19195551214 is not in allowed numbers, the request is not sent")
+}
+
+func TestTestModeBlocksRequests(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls",
+		Method:           http.MethodPost,
+		EstimatedContent: `{"from":"+19195551211","to":"+19195551212"}`,
+		HeadersToSend:    map[string]string{"Location": "/v1/users/{userId}/calls/123"}}})
+	defer server.Close()
+	WithTestMode("+19195551212")(api)
+	id, err := api.CreateCall(&CreateCallData{From: "+19195551211", To: "+19195551212"})
+	expectNil(t, err)
+	expect(t, id, "123")
+	shouldFail(t, func() (interface{}, error) {
+		return api.CreateCall(&CreateCallData{From: "+19195551211", To: "+19195551214"})
+	})
+	shouldFail(t, func() (interface{}, error) { return api.TransferCall("123", "+19195551214") })
+	shouldFail(t, func() (interface{}, error) {
+		return api.CreateMessage(&CreateMessageData{From: "+19195551211", To: "+19195551214", Text: "Hello"})
+	})
+	shouldFail(t, func() (interface{}, error) {
+		return api.CreateMessages(&CreateMessageData{To: "+19195551212"}, &CreateMessageData{To: "+19195551214"})
+	})
+	shouldFail(t, func() (interface{}, error) {
+		return api.CreateMessageV2(&CreateMessageDataV2{To: []string{"+19195551212", "+19195551214"}})
+	})
+	shouldFail(t, func() (interface{}, error) {
+		return api.CreateMessagesV2(&CreateMessageDataV2{To: "+19195551212"}, nil, &CreateMessageDataV2{To: "+19195551214"})
+	})
+}
+
+func TestMessageV2Recipients(t *testing.T) {
+	type number string
+	first, second := "+1", "+2"
+	valid := []interface{}{[]string{"+1", "+2"}, []interface{}{"+1", "+2"}, []*string{&first, &second}, []number{"+1", "+2"}, [2]string{"+1", "+2"}}
+	for _, to := range valid {
+		numbers, err := messageV2Recipients(to)
+		expectNil(t, err)
+		expect(t, numbers, []string{"+1", "+2"})
+	}
+	numbers, err := messageV2Recipients(number("+1"))
+	expectNil(t, err)
+	expect(t, numbers, []string{"+1"})
+	numbers, _ = messageV2Recipients(nil)
+	expect(t, len(numbers), 0)
+	for _, to := range []interface{}{123, []int{1}, []interface{}{"+1", 2}, []*string{nil}, map[string]string{"to": "+1"}} {
+		shouldFail(t, func() (interface{}, error) { return messageV2Recipients(to) })
+	}
+}
+
+func TestCreateMessageV2WithUnknownRecipientTypeInTestMode(t *testing.T) {
+	api, _ := New("userId", "apiToken", "apiSecret", WithTestMode("+19195551212"))
+	shouldFail(t, func() (interface{}, error) { return api.CreateMessageV2(&CreateMessageDataV2{To: 19195551212}) })
+	shouldFail(t, func() (interface{}, error) { return api.CreateMessagesV2(&CreateMessageDataV2{To: []int{1}}) })
+}
@@ -0,0 +1,134 @@
+package bandwidth
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timing is time breakdown of an API request (collected if CollectTiming of the client is set)
+// Phases which didn't happen (like DNS lookup and connect for reused connections) have zero duration.
+type Timing struct {
+	Method string
+	URL    string
+	Start  time.Time
+
+	DNSLookup    time.Duration
+	Connect      time.Duration
+	TLSHandshake time.Duration
+	// TimeToFirstByte is time from start of the request to first byte of the response
+	TimeToFirstByte time.Duration
+	// Total is time from start of the request to the moment when the response is handled
+	Total time.Duration
+
+	ReusedConnection bool
+}
+
+// WithTiming makes the client collect timing of requests (see LastTiming())
+// example: api := bandwidth.New("userId", "apiToken", "apiSecret", bandwidth.WithTiming())
+func WithTiming() Option {
+	return func(c *Client) error {
+		c.CollectTiming = true
+		return nil
+	}
+}
+
+// LastTiming returns timing of last completed request (or nil if CollectTiming is not set or there were no requests yet)
+// It is shared by all requests of the client, so with concurrent requests it can be timing of another caller's request.
+// Use ContextWithTiming() to get timing of your own call.
+// example: call, err := api.GetCall("callId")
+// fmt.Println(api.LastTiming().TimeToFirstByte)
+func (c *Client) LastTiming() *Timing {
+	state := c.state()
+	state.timingMutex.Lock()
+	defer state.timingMutex.Unlock()
+	if state.lastTiming == nil {
+		return nil
+	}
+	timing := *state.lastTiming
+	return &timing
+}
+
+type timingContextKey struct{}
+
+// ContextWithTiming returns context which makes requests of the client fill the timing (CollectTiming is not required)
+// Use it with WithContext(). If a method makes several requests, the timing is filled by the last one.
+// example: var timing bandwidth.Timing
+// call, err := api.WithContext(bandwidth.ContextWithTiming(ctx, &timing)).GetCall("callId")
+// fmt.Println(timing.Total)
+func ContextWithTiming(parent context.Context, timing *Timing) context.Context {
+	return context.WithValue(parent, timingContextKey{}, timing)
+}
+
+// timingOfContext returns timing which should be filled for requests with the context (or nil)
+func timingOfContext(ctx context.Context) *Timing {
+	timing, _ := ctx.Value(timingContextKey{}).(*Timing)
+	return timing
+}
+
+// requestTimer collects timing of one request (trace hooks can be called from transport goroutines)
+type requestTimer struct {
+	mutex                            sync.Mutex
+	timing                           Timing
+	dnsStart, connectStart, tlsStart time.Time
+}
+
+// traceRequest attaches trace hooks to the request
+// It returns request with trace context and timer of the request
+func traceRequest(request *http.Request) (*http.Request, *requestTimer) {
+	timer := &requestTimer{timing: Timing{Method: request.Method, URL: request.URL.String(), Start: time.Now()}}
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			timer.update(func(t *Timing) { t.ReusedConnection = info.Reused })
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			timer.update(func(*Timing) { timer.dnsStart = time.Now() })
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			timer.update(func(t *Timing) { t.DNSLookup = time.Since(timer.dnsStart) })
+		},
+		ConnectStart: func(string, string) {
+			timer.update(func(*Timing) { timer.connectStart = time.Now() })
+		},
+		ConnectDone: func(string, string, error) {
+			timer.update(func(t *Timing) { t.Connect = time.Since(timer.connectStart) })
+		},
+		TLSHandshakeStart: func() {
+			timer.update(func(*Timing) { timer.tlsStart = time.Now() })
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			timer.update(func(t *Timing) { t.TLSHandshake = time.Since(timer.tlsStart) })
+		},
+		GotFirstResponseByte: func() {
+			timer.update(func(t *Timing) { t.TimeToFirstByte = time.Since(t.Start) })
+		},
+	}
+	return request.WithContext(httptrace.WithClientTrace(request.Context(), trace)), timer
+}
+
+func (timer *requestTimer) update(change func(*Timing)) {
+	timer.mutex.Lock()
+	defer timer.mutex.Unlock()
+	change(&timer.timing)
+}
+
+// finishTiming stores timing of completed request to the timing of its context and as last one (if CollectTiming is set)
+func (c *Client) finishTiming(timer *requestTimer, target *Timing) {
+	timer.mutex.Lock()
+	timer.timing.Total = time.Since(timer.timing.Start)
+	timing := timer.timing
+	timer.mutex.Unlock()
+	if target != nil {
+		*target = timing
+	}
+	if !c.CollectTiming {
+		return
+	}
+	state := c.state()
+	state.timingMutex.Lock()
+	defer state.timingMutex.Unlock()
+	state.lastTiming = &timing
+}
@@ -0,0 +1,75 @@
+package bandwidth
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestLastTiming(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/123",
+		Method:        http.MethodGet,
+		ContentToSend: `{"id": "123"}`}})
+	defer server.Close()
+	if api.LastTiming() != nil {
+		t.Error("Should be nil if timing is not collected")
+	}
+	WithTiming()(api)
+	_, err := api.GetCall("123")
+	expectNil(t, err)
+	timing := api.LastTiming()
+	if timing == nil {
+		t.Fatal("Timing should be collected")
+	}
+	expect(t, timing.Method, http.MethodGet)
+	expect(t, timing.URL, server.URL+"/v1/users/userId/calls/123")
+	if timing.Total <= 0 || timing.TimeToFirstByte <= 0 || timing.TimeToFirstByte > timing.Total {
+		t.Errorf("Unexpected durations %v", timing)
+	}
+}
+
+func TestLastTimingOfFailedRequest(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:     "/v1/users/userId/calls/123",
+		Method:           http.MethodGet,
+		StatusCodeToSend: http.StatusNotFound}})
+	defer server.Close()
+	api.CollectTiming = true
+	shouldFail(t, func() (interface{}, error) { return api.GetCall("123") })
+	if api.LastTiming() == nil {
+		t.Error("Timing should be collected for failed requests too")
+	}
+}
+
+func TestContextWithTiming(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/1",
+		ContentToSend: `{"id": "1"}`}, RequestHandler{
+		PathAndQuery:  "/v1/users/userId/calls/2",
+		ContentToSend: `{"id": "2"}`}})
+	defer server.Close()
+	timings := make([]Timing, 20)
+	var wg sync.WaitGroup
+	for i := range timings {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := strconv.Itoa(i%2 + 1)
+			_, err := api.WithContext(ContextWithTiming(context.Background(), &timings[i])).GetCall(id)
+			expectNil(t, err)
+		}(i)
+	}
+	wg.Wait()
+	for i, timing := range timings {
+		expect(t, timing.URL, server.URL+"/v1/users/userId/calls/"+strconv.Itoa(i%2+1))
+		if timing.Total <= 0 {
+			t.Errorf("Unexpected durations %v", timing)
+		}
+	}
+	if api.LastTiming() != nil {
+		t.Error("Last timing should be collected only if CollectTiming is set")
+	}
+}
@@ -1,6 +1,7 @@
 package bandwidth
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -9,40 +10,55 @@ const transcriptionsPath = "transcriptions"
 
 // Transcription struct
 type Transcription struct {
-	ID                 string `json:"id"`
-	ChargeableDuration int    `json:"chargeableDuration"`
-	Text               string `json:"text"`
-	TextSize           int    `json:"textSize"`
-	TextURL            string `json:"textUrl"`
-	Time               string `json:"time"`
+	ID                 string  `json:"id"`
+	State              string  `json:"state"`
+	ChargeableDuration int     `json:"chargeableDuration"`
+	Cost               float64 `json:"cost"`
+	Text               string  `json:"text"`
+	TextSize           int     `json:"textSize"`
+	TextURL            string  `json:"textUrl"`
+	Time               string  `json:"time"`
+}
+
+// UnmarshalJSON decodes the transcription (cost can be number or string)
+func (t *Transcription) UnmarshalJSON(data []byte) error {
+	type rawTranscription Transcription
+	raw := struct {
+		*rawTranscription
+		Cost json.Number `json:"cost"`
+	}{rawTranscription: (*rawTranscription)(t)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	t.Cost = 0
+	if raw.Cost != "" {
+		cost, err := raw.Cost.Float64()
+		if err != nil {
+			return fmt.Errorf("Invalid transcription cost %q", raw.Cost)
+		}
+		t.Cost = cost
+	}
+	return nil
 }
 
 // GetRecordingTranscriptions returns list of all transcriptions for a recording
 // It returns list of Transcription instances or error
 func (api *Client) GetRecordingTranscriptions(id string) ([]*Transcription, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s/%s", api.concatUserPath(recordingsPath), id, transcriptionsPath), &[]*Transcription{})
-	if err != nil {
-		return nil, err
-	}
-	return *(result.(*[]*Transcription)), nil
+	return listTyped[Transcription](api, fmt.Sprintf("%s/%s/%s", api.concatUserPath(recordingsPath), id, transcriptionsPath), nil)
 }
 
 // CreateRecordingTranscription creates a new transcription for a recording
 // It returns ID of created transcription or error
 func (api *Client) CreateRecordingTranscription(id string) (string, error) {
-	_, headers, err := api.makeRequest(http.MethodPost, fmt.Sprintf("%s/%s/%s", api.concatUserPath(recordingsPath), id, transcriptionsPath))
+	result, headers, err := api.makeRequest(http.MethodPost, fmt.Sprintf("%s/%s/%s", api.concatUserPath(recordingsPath), id, transcriptionsPath))
 	if err != nil {
 		return "", err
 	}
-	return getIDFromLocationHeader(headers), nil
+	return getCreatedID(result, headers)
 }
 
 // GetRecordingTranscription returns   single enpoint for a recording
 // It returns Transcription instance or error
 func (api *Client) GetRecordingTranscription(recordingID string, transcriptionID string) (*Transcription, error) {
-	result, _, err := api.makeRequest(http.MethodGet, fmt.Sprintf("%s/%s/%s/%s", api.concatUserPath(recordingsPath), recordingID, transcriptionsPath, transcriptionID), &Transcription{})
-	if err != nil {
-		return nil, err
-	}
-	return result.(*Transcription), nil
+	return getTyped[Transcription](api, fmt.Sprintf("%s/%s/%s/%s", api.concatUserPath(recordingsPath), recordingID, transcriptionsPath, transcriptionID), nil)
 }
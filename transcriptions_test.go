@@ -66,7 +66,11 @@ func TestGetRecordingTranscription(t *testing.T) {
 		Method:       http.MethodGet,
 		ContentToSend: `{
 			"id": "{transcriptionId2}",
-			"text": "transcription2"
+			"text": "transcription2",
+			"state": "completed",
+			"chargeableDuration": 60,
+			"cost": "0.05",
+			"time": "2014-12-23T23:08:59Z"
 		}`}})
 	defer server.Close()
 	result, err := api.GetRecordingTranscription("123", "456")
@@ -75,6 +79,23 @@ func TestGetRecordingTranscription(t *testing.T) {
 		return
 	}
 	expect(t, result.Text, "transcription2")
+	expect(t, result.Time, "2014-12-23T23:08:59Z")
+	expect(t, result.State, "completed")
+	expect(t, result.ChargeableDuration, 60)
+	expect(t, result.Cost, 0.05)
+}
+
+func TestGetRecordingTranscriptionsWithNumericCost(t *testing.T) {
+	server, api := startMockServer(t, []RequestHandler{RequestHandler{
+		PathAndQuery:  "/v1/users/userId/recordings/123/transcriptions",
+		Method:        http.MethodGet,
+		ContentToSend: `[{"id": "456", "cost": 0.05}, {"id": "789"}]`}})
+	defer server.Close()
+	result, err := api.GetRecordingTranscriptions("123")
+	expectNil(t, err)
+	expect(t, len(result), 2)
+	expect(t, result[0].Cost, 0.05)
+	expect(t, result[1].Cost, 0.0)
 }
 
 func TestGetRecordingTranscriptionFail(t *testing.T) {
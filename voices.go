@@ -0,0 +1,70 @@
+package bandwidth
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VoicesVersion is version of the built-in list of text to speech voices returned by GetAvailableVoices()
+const VoicesVersion = "2017.1"
+
+// Voice genders
+const (
+	VoiceGenderFemale = "female"
+	VoiceGenderMale   = "male"
+)
+
+// Voice is text to speech voice which can be used in PlayAudioData and GatherPromptData
+type Voice struct {
+	Name   string
+	Locale string
+	Gender string
+}
+
+var availableVoices = []Voice{
+	Voice{"susan", "en_US", VoiceGenderFemale},
+	Voice{"julie", "en_US", VoiceGenderFemale},
+	Voice{"kate", "en_US", VoiceGenderFemale},
+	Voice{"dave", "en_US", VoiceGenderMale},
+	Voice{"paul", "en_US", VoiceGenderMale},
+	Voice{"bridget", "en_UK", VoiceGenderFemale},
+	Voice{"simon", "en_UK", VoiceGenderMale},
+	Voice{"esperanza", "es_MX", VoiceGenderFemale},
+	Voice{"violeta", "es_ES", VoiceGenderFemale},
+	Voice{"jorge", "es_ES", VoiceGenderMale},
+	Voice{"jolie", "fr_FR", VoiceGenderFemale},
+	Voice{"bernard", "fr_FR", VoiceGenderMale},
+	Voice{"katrin", "de_DE", VoiceGenderFemale},
+	Voice{"stefan", "de_DE", VoiceGenderMale},
+	Voice{"paola", "it_IT", VoiceGenderFemale},
+	Voice{"luca", "it_IT", VoiceGenderMale},
+}
+
+// GetAvailableVoices returns supported text to speech voices
+// The API has no endpoint for that, so built-in list is returned (see VoicesVersion).
+// It returns list of Voice instances or error
+func (api *Client) GetAvailableVoices() ([]Voice, error) {
+	voices := make([]Voice, len(availableVoices))
+	copy(voices, availableVoices)
+	return voices, nil
+}
+
+// validateVoice checks that the voice (if set) is supported and matches locale and gender (if they are set)
+func validateVoice(voice, locale, gender string) error {
+	if voice == "" {
+		return nil
+	}
+	for _, v := range availableVoices {
+		if !strings.EqualFold(v.Name, voice) {
+			continue
+		}
+		if locale != "" && !strings.EqualFold(v.Locale, locale) {
+			return fmt.Errorf("Voice %q doesn't support locale %q (use %q)", voice, locale, v.Locale)
+		}
+		if gender != "" && !strings.EqualFold(v.Gender, gender) {
+			return fmt.Errorf("Voice %q is %s", voice, v.Gender)
+		}
+		return nil
+	}
+	return fmt.Errorf("Unsupported voice %q (see GetAvailableVoices())", voice)
+}
@@ -0,0 +1,40 @@
+package bandwidth
+
+import (
+	"testing"
+)
+
+func TestGetAvailableVoices(t *testing.T) {
+	api := getAPI()
+	voices, err := api.GetAvailableVoices()
+	expectNil(t, err)
+	expect(t, len(voices), len(availableVoices))
+	expect(t, voices[0], Voice{"susan", "en_US", VoiceGenderFemale})
+	voices[0].Name = "changed"
+	expect(t, availableVoices[0].Name, "susan")
+}
+
+func TestValidateVoice(t *testing.T) {
+	expectNil(t, validateVoice("", "", ""))
+	expectNil(t, validateVoice("paul", "", ""))
+	expectNil(t, validateVoice("Paul", "en_US", "male"))
+	expectNil(t, validateVoice("", "fr_FR", "female"))
+}
+
+func TestValidateVoiceFail(t *testing.T) {
+	shouldFail(t, func() (interface{}, error) { return nil, validateVoice("unknown", "", "") })
+	shouldFail(t, func() (interface{}, error) { return nil, validateVoice("paul", "fr_FR", "") })
+	shouldFail(t, func() (interface{}, error) { return nil, validateVoice("paul", "", "female") })
+}
+
+func TestPlayAudioWithUnsupportedVoice(t *testing.T) {
+	api := getAPI()
+	data := &PlayAudioData{Sentence: "Hello", Voice: "robot"}
+	shouldFail(t, func() (interface{}, error) { return nil, api.PlayAudioToCall("123", data) })
+	shouldFail(t, func() (interface{}, error) { return nil, api.PlayAudioToBridge("123", data) })
+	shouldFail(t, func() (interface{}, error) { return nil, api.PlayAudioToConference("123", data) })
+	shouldFail(t, func() (interface{}, error) { return nil, api.PlayAudioToConferenceMember("123", "456", data) })
+	shouldFail(t, func() (interface{}, error) {
+		return api.CreateGather("123", &CreateGatherData{Prompt: &GatherPromptData{Sentence: "Hello", Voice: "robot"}})
+	})
+}
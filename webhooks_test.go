@@ -0,0 +1,290 @@
+package bandwidth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func createEventRequest(body string) *http.Request {
+	return httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader(body))
+}
+
+func TestParseEventWithSms(t *testing.T) {
+	r := createEventRequest(`{
+		"eventType": "sms",
+		"direction": "in",
+		"messageId": "m-123",
+		"messageUri": "https://api.catapult.inetwork.com/v1/users/u-123/messages/m-123",
+		"from": "+13233326955",
+		"to": "+13233326956",
+		"text": "Hello",
+		"applicationId": "a-123",
+		"time": "2012-11-14T16:13:06.076Z",
+		"state": "received",
+		"segmentCount": 2
+	}`)
+	event, err := ParseEvent(r)
+	if err != nil {
+		t.Fatal("Failed call of ParseEvent()")
+	}
+	e, ok := event.(*MessageEvent)
+	if !ok {
+		t.Fatalf("Unexpected event type %T", event)
+	}
+	expect(t, e.EventType(), "sms")
+	expect(t, e.Direction, "in")
+	expect(t, e.MessageID, "m-123")
+	expect(t, e.Text, "Hello")
+	expect(t, e.Time, "2012-11-14T16:13:06.076Z")
+	expect(t, e.SegmentCount, 2)
+	expect(t, len(e.Media), 0)
+}
+
+func TestParseEventWithDeliveryReceipt(t *testing.T) {
+	r := createEventRequest(`{"eventType": "sms", "messageId": "m-123", "deliveryState": "not-delivered", "deliveryCode": "4770"}`)
+	event, err := ParseEvent(r)
+	expectNil(t, err)
+	e := event.(*MessageEvent)
+	expect(t, e.ErrorCode(), 4770)
+	expect(t, e.FailureCategory(), FailureCategorySpamBlocked)
+}
+
+func TestParseEventWithMms(t *testing.T) {
+	body := `{
+		"eventType": "mms",
+		"direction": "in",
+		"messageId": "m-123",
+		"from": "+13233326955",
+		"to": "+13233326956",
+		"text": "",
+		"media": ["https://api.catapult.inetwork.com/v1/users/u-123/media/image.jpg"],
+		"state": "received"
+	}`
+	r := createEventRequest(body)
+	event, err := ParseEvent(r)
+	if err != nil {
+		t.Fatal("Failed call of ParseEvent()")
+	}
+	e := event.(*MessageEvent)
+	expect(t, e.EventType(), "mms")
+	expect(t, e.Media, []string{"https://api.catapult.inetwork.com/v1/users/u-123/media/image.jpg"})
+	expect(t, readText(t, r.Body), body)
+}
+
+func TestParseEventWithIncomingCall(t *testing.T) {
+	event, err := ParseEvent(createEventRequest(`{
+		"eventType": "incomingcall",
+		"from": "+13233326955",
+		"to": "+13233326956",
+		"callId": "c-123",
+		"callUri": "https://api.catapult.inetwork.com/v1/users/u-123/calls/c-123",
+		"callState": "active",
+		"applicationId": "a-123",
+		"time": "2012-11-14T16:13:06.076Z",
+		"diversion": {
+			"origTo": "+13233326957",
+			"reason": "unconditional",
+			"counter": "1"
+		}
+	}`))
+	if err != nil {
+		t.Fatal("Failed call of ParseEvent()")
+	}
+	e := event.(*IncomingCallEvent)
+	expect(t, e.EventType(), "incomingcall")
+	expect(t, e.CallID, "c-123")
+	expect(t, e.IsForwarded(), true)
+	expect(t, e.Diversion.OrigTo, "+13233326957")
+	expect(t, e.Diversion.Reason, "unconditional")
+	expect(t, e.Diversion.Counter, "1")
+	event, _ = ParseEvent(createEventRequest(`{"eventType": "incomingcall", "callId": "c-123"}`))
+	expect(t, event.(*IncomingCallEvent).IsForwarded(), false)
+}
+
+func TestParseEventWithIncomingSIPCall(t *testing.T) {
+	event, err := ParseEvent(createEventRequest(`{
+		"eventType": "incomingcall",
+		"from": "sip:john@example.com",
+		"to": "+13233326956",
+		"callId": "c-123",
+		"sipUri": "sip:john@example.com:5060"
+	}`))
+	if err != nil {
+		t.Fatal("Failed call of ParseEvent()")
+	}
+	e := event.(*IncomingCallEvent)
+	expect(t, e.From, "sip:john@example.com")
+	expect(t, e.SipURI, "sip:john@example.com:5060")
+}
+
+func TestParseEventWithTimeout(t *testing.T) {
+	event, err := ParseEvent(createEventRequest(`{
+		"eventType": "timeout",
+		"from": "+13233326955",
+		"to": "+13233326956",
+		"callId": "c-123",
+		"callUri": "https://api.catapult.inetwork.com/v1/users/u-123/calls/c-123",
+		"callState": "completed",
+		"time": "2012-11-14T16:13:06.076Z"
+	}`))
+	if err != nil {
+		t.Fatal("Failed call of ParseEvent()")
+	}
+	e, ok := event.(*CallTimeoutEvent)
+	if !ok {
+		t.Fatalf("Unexpected event type %T", event)
+	}
+	expect(t, e.EventType(), CallEventTimeout)
+	expect(t, e.CallID, "c-123")
+	expect(t, e.CallState, "completed")
+	expect(t, e.To, "+13233326956")
+}
+
+func TestParseEventWithMaxDuration(t *testing.T) {
+	event, err := ParseEvent(createEventRequest(`{
+		"eventType": "maxDuration",
+		"from": "+13233326955",
+		"to": "+13233326956",
+		"callId": "c-123",
+		"callState": "completed",
+		"cause": "NORMAL_CLEARING",
+		"time": "2012-11-14T20:13:06.076Z"
+	}`))
+	if err != nil {
+		t.Fatal("Failed call of ParseEvent()")
+	}
+	e, ok := event.(*MaxDurationEvent)
+	if !ok {
+		t.Fatalf("Unexpected event type %T", event)
+	}
+	expect(t, e.EventType(), CallEventMaxDuration)
+	expect(t, e.CallID, "c-123")
+	expect(t, e.Cause, "NORMAL_CLEARING")
+}
+
+func TestParseEventWithRecording(t *testing.T) {
+	event, err := ParseEvent(createEventRequest(`{
+		"eventType": "recording",
+		"callId": "c-123",
+		"recordingId": "r-123",
+		"recordingUri": "https://api.catapult.inetwork.com/v1/users/u-123/recordings/r-123",
+		"state": "paused",
+		"startTime": "2013-02-08T12:05:17.807Z"
+	}`))
+	if err != nil {
+		t.Fatal("Failed call of ParseEvent()")
+	}
+	e := event.(*RecordingEvent)
+	expect(t, e.EventType(), "recording")
+	expect(t, e.CallID, "c-123")
+	expect(t, e.RecordingID, "r-123")
+	expect(t, e.State, RecordingStatePaused)
+	expect(t, e.StartTime, "2013-02-08T12:05:17.807Z")
+}
+
+func TestParseEventWithUnknownEvent(t *testing.T) {
+	event, err := ParseEvent(createEventRequest(`{"eventType": "something", "field": "value"}`))
+	if err != nil {
+		t.Fatal("Failed call of ParseEvent()")
+	}
+	e := event.(*UnknownEvent)
+	expect(t, e.EventType(), "something")
+	expect(t, e.Data["field"], "value")
+}
+
+func TestParseEventFail(t *testing.T) {
+	shouldFail(t, func() (interface{}, error) { return ParseEvent(createEventRequest(`invalid json`)) })
+	shouldFail(t, func() (interface{}, error) { return ParseEvent(createEventRequest(`{"eventType": "sms", "text": 1}`)) })
+}
+
+func TestParseEventFromRequestWithQuery(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/callback?eventType=incomingcall&callId=c-123&from=%2B13233326955&to=%2B13233326956&callState=active&tag=tag1", nil)
+	event, err := ParseEventFromRequest(r)
+	if err != nil {
+		t.Fatal("Failed call of ParseEventFromRequest()")
+	}
+	e := event.(*IncomingCallEvent)
+	expect(t, e.EventType(), "incomingcall")
+	expect(t, e.Tag, "tag1")
+	expect(t, e.CallID, "c-123")
+	expect(t, e.From, "+13233326955")
+	expect(t, e.To, "+13233326956")
+	expect(t, e.IsForwarded(), false)
+}
+
+func TestParseEventFromRequestWithForm(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/callback", strings.NewReader("eventType=mms&messageId=m-123&segmentCount=2&media=url1&media=url2"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	event, err := ParseEventFromRequest(r)
+	if err != nil {
+		t.Fatal("Failed call of ParseEventFromRequest()")
+	}
+	e := event.(*MessageEvent)
+	expect(t, e.EventType(), "mms")
+	expect(t, e.MessageID, "m-123")
+	expect(t, e.SegmentCount, 2)
+	expect(t, len(e.Media), 2)
+	expect(t, e.Media[1], "url2")
+}
+
+func TestParseEventFromRequestWithJSON(t *testing.T) {
+	r := createEventRequest(`{"eventType": "sms", "messageId": "m-123", "segmentCount": 1}`)
+	r.Header.Set("Content-Type", "application/json")
+	event, err := ParseEventFromRequest(r)
+	if err != nil {
+		t.Fatal("Failed call of ParseEventFromRequest()")
+	}
+	e := event.(*MessageEvent)
+	expect(t, e.MessageID, "m-123")
+	expect(t, e.SegmentCount, 1)
+}
+
+func TestParseEventFromRequestWithUnknownEvent(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/callback?eventType=something&field=value", nil)
+	event, err := ParseEventFromRequest(r)
+	if err != nil {
+		t.Fatal("Failed call of ParseEventFromRequest()")
+	}
+	e := event.(*UnknownEvent)
+	expect(t, e.EventType(), "something")
+	expect(t, e.Data["field"], "value")
+}
+
+func TestParseEventFromRequestFail(t *testing.T) {
+	shouldFail(t, func() (interface{}, error) {
+		return ParseEventFromRequest(httptest.NewRequest(http.MethodGet, "/callback?callId=c-123", nil))
+	})
+	shouldFail(t, func() (interface{}, error) {
+		return ParseEventFromRequest(httptest.NewRequest(http.MethodGet, "/callback?eventType=sms&segmentCount=many", nil))
+	})
+}
+
+func TestSetEventFieldsWithBool(t *testing.T) {
+	type event struct {
+		BaseEvent
+		Forwarded bool `json:"forwarded"`
+	}
+	e := &event{}
+	expectNil(t, setEventFields(reflect.ValueOf(e).Elem(), url.Values{"eventType": {"custom"}, "forwarded": {"true"}}))
+	expect(t, e.Forwarded, true)
+	expect(t, e.EventType(), "custom")
+	shouldFail(t, func() (interface{}, error) {
+		return nil, setEventFields(reflect.ValueOf(e).Elem(), url.Values{"forwarded": {"maybe"}})
+	})
+}
+
+func TestVerifyCallbackAuth(t *testing.T) {
+	r := createEventRequest(`{"eventType": "sms"}`)
+	expect(t, VerifyCallbackAuth(r, "user", "password"), false)
+	r.SetBasicAuth("user", "password")
+	expect(t, VerifyCallbackAuth(r, "user", "password"), true)
+	expect(t, VerifyCallbackAuth(r, "user", "other"), false)
+	expect(t, VerifyCallbackAuth(r, "other", "password"), false)
+	event, err := ParseEvent(r)
+	expectNil(t, err)
+	expect(t, event.EventType(), "sms")
+}